@@ -28,6 +28,7 @@ type ComponentInfo struct {
 	PackagePath   string
 	FileName      string
 	FactoryFunc   string
+	Constructor   bool // 工厂函数带参数，生成构造函数注册（参数在依赖注入时解析）
 	Layer         Layer
 }
 
@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/urfave/cli/v3"
+)
+
+// fetchTimeout 请求依赖图端点的超时时间
+const fetchTimeout = 10 * time.Second
+
+func GetCommand() *cli.Command {
+	var url string
+	var inputFile string
+	var format string
+	var outputFile string
+	var check bool
+
+	return &cli.Command{
+		Name:  "graph",
+		Usage: "导出依赖图",
+		Description: `导出应用的跨层依赖图（Manager、Entity、Repository、Service、Controller、Middleware、Listener、Scheduler
+及其 inject 依赖），支持 DOT、Mermaid 和 JSON 格式
+
+依赖图来源：
+  - --url: 运行中应用的 /api/dependencies 端点（管理端或 debug 模式）
+  - --input: engine.DependencyGraph() 或端点导出的 JSON 文件
+
+使用 --check 时，存在违反分层规则的依赖或循环依赖则返回非零退出码，可用于 CI 检查`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "url",
+				Aliases:     []string{"u"},
+				Value:       "http://127.0.0.1:9090/api/dependencies",
+				Usage:       "依赖图端点地址",
+				Destination: &url,
+			},
+			&cli.StringFlag{
+				Name:        "input",
+				Aliases:     []string{"i"},
+				Usage:       "依赖图 JSON 文件（指定后不请求端点）",
+				Destination: &inputFile,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Aliases:     []string{"f"},
+				Value:       container.GraphFormatDOT,
+				Usage:       "输出格式 (dot/mermaid/json)",
+				Destination: &format,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "输出文件（默认输出到标准输出）",
+				Destination: &outputFile,
+			},
+			&cli.BoolFlag{
+				Name:        "check",
+				Usage:       "存在分层违规或循环依赖时返回非零退出码",
+				Destination: &check,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := run(ctx, url, inputFile, format, outputFile, check); err != nil {
+				fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+				return cli.Exit("", 1)
+			}
+			return nil
+		},
+	}
+}
+
+// run 读取依赖图并按格式输出，check 为 true 时检查分层违规和循环依赖
+func run(ctx context.Context, url, inputFile, format, outputFile string, check bool) error {
+	var graph *container.DependencyGraph
+	var err error
+	if inputFile != "" {
+		graph, err = readGraph(inputFile)
+	} else {
+		graph, err = fetchGraph(ctx, url)
+	}
+	if err != nil {
+		return err
+	}
+
+	out, err := graph.Render(format)
+	if err != nil {
+		return err
+	}
+	if outputFile == "" {
+		fmt.Print(out)
+	} else if err := os.WriteFile(outputFile, []byte(out), 0644); err != nil {
+		return fmt.Errorf("写入输出文件失败: %w", err)
+	}
+
+	if check {
+		return checkGraph(graph)
+	}
+	return nil
+}
+
+// readGraph 从 JSON 文件读取依赖图
+func readGraph(filename string) (*container.DependencyGraph, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取依赖图文件失败: %w", err)
+	}
+	return decodeGraph(data)
+}
+
+// fetchGraph 从依赖图端点获取 JSON 格式的依赖图
+func fetchGraph(ctx context.Context, url string) (*container.DependencyGraph, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	q := req.URL.Query()
+	q.Set("format", container.GraphFormatJSON)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求依赖图失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求依赖图失败: %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return decodeGraph(data)
+}
+
+// decodeGraph 解析 JSON 格式的依赖图
+func decodeGraph(data []byte) (*container.DependencyGraph, error) {
+	var graph container.DependencyGraph
+	if err := json.Unmarshal(data, &graph); err != nil {
+		return nil, fmt.Errorf("解析依赖图失败: %w", err)
+	}
+	return &graph, nil
+}
+
+// checkGraph 检查分层违规和循环依赖，有问题时输出到标准错误并返回错误
+func checkGraph(graph *container.DependencyGraph) error {
+	violations := graph.LayerViolations()
+	cycles := graph.Cycles()
+	for _, edge := range violations {
+		fmt.Fprintf(os.Stderr, "分层违规: %s.%s → %s\n", edge.From, edge.Field, edge.To)
+	}
+	for _, cycle := range cycles {
+		fmt.Fprintf(os.Stderr, "循环依赖: %s\n", cycle)
+	}
+	if len(violations) > 0 || len(cycles) > 0 {
+		return fmt.Errorf("发现 %d 个分层违规、%d 个循环依赖", len(violations), len(cycles))
+	}
+	return nil
+}
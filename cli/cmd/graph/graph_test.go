@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testGraphJSON = `{
+  "nodes": [
+    {"id": "Repository:repositories.IUserRepository", "layer": "Repository", "interface": "repositories.IUserRepository", "name": "UserRepository", "impl": "userRepositoryImpl"},
+    {"id": "Service:services.IUserService", "layer": "Service", "interface": "services.IUserService", "name": "UserService", "impl": "userServiceImpl"},
+    {"id": "Controller:controllers.IUserController", "layer": "Controller", "interface": "controllers.IUserController", "name": "UserController", "impl": "userControllerImpl"}
+  ],
+  "edges": [
+    {"from": "Controller:controllers.IUserController", "to": "Repository:repositories.IUserRepository", "field": "Repo", "violation": true},
+    {"from": "Controller:controllers.IUserController", "to": "Service:services.IUserService", "field": "UserService"},
+    {"from": "Service:services.IUserService", "to": "Repository:repositories.IUserRepository", "field": "Repo"}
+  ]
+}`
+
+func TestGetCommand(t *testing.T) {
+	t.Run("创建依赖图命令", func(t *testing.T) {
+		cmd := GetCommand()
+
+		if cmd.Name != "graph" {
+			t.Errorf("期望命令名为 'graph', 实际: %s", cmd.Name)
+		}
+		if cmd.Description == "" {
+			t.Error("Description 不能为空")
+		}
+		if cmd.Action == nil {
+			t.Error("Action 不能为 nil")
+		}
+
+		flagMap := make(map[string]bool)
+		for _, flag := range cmd.Flags {
+			flagMap[flag.Names()[0]] = true
+		}
+		for _, expected := range []string{"url", "input", "format", "output", "check"} {
+			if !flagMap[expected] {
+				t.Errorf("缺少参数: %s", expected)
+			}
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "graph.json")
+	if err := os.WriteFile(input, []byte(testGraphJSON), 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	t.Run("从端点获取_导出Mermaid", func(t *testing.T) {
+		var gotFormat string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotFormat = r.URL.Query().Get("format")
+			_, _ = w.Write([]byte(testGraphJSON))
+		}))
+		defer server.Close()
+
+		output := filepath.Join(dir, "graph.mmd")
+		if err := run(context.Background(), server.URL+"/api/dependencies", "", "mermaid", output, false); err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		if gotFormat != "json" {
+			t.Errorf("期望请求 JSON 格式, 实际: %q", gotFormat)
+		}
+		data, _ := os.ReadFile(output)
+		if !strings.HasPrefix(string(data), "flowchart LR\n") || !strings.Contains(string(data), `n2 -->|"UserService"| n1`) {
+			t.Errorf("Mermaid 输出不正确:\n%s", data)
+		}
+	})
+
+	t.Run("从文件读取_导出DOT", func(t *testing.T) {
+		output := filepath.Join(dir, "graph.dot")
+		if err := run(context.Background(), "", input, "dot", output, false); err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		data, _ := os.ReadFile(output)
+		if !strings.Contains(string(data), `[label="Repo", color=red, fontcolor=red];`) {
+			t.Errorf("DOT 输出未标记分层违规:\n%s", data)
+		}
+	})
+
+	t.Run("check_存在分层违规返回错误", func(t *testing.T) {
+		err := run(context.Background(), "", input, "json", filepath.Join(dir, "out.json"), true)
+		if err == nil || !strings.Contains(err.Error(), "1 个分层违规") {
+			t.Errorf("期望返回分层违规错误, 实际: %v", err)
+		}
+	})
+
+	t.Run("端点返回错误状态", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+		if err := run(context.Background(), server.URL, "", "dot", "", false); err == nil || !strings.Contains(err.Error(), "404") {
+			t.Errorf("期望返回 404 错误, 实际: %v", err)
+		}
+	})
+
+	t.Run("不支持的格式", func(t *testing.T) {
+		if err := run(context.Background(), "", input, "svg", "", false); err == nil {
+			t.Error("期望返回错误")
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/lite-lake/litecore-go/cli/cmd/generate"
+	"github.com/lite-lake/litecore-go/cli/cmd/graph"
 	"github.com/lite-lake/litecore-go/cli/cmd/scaffold"
 	"github.com/urfave/cli/v3"
 )
@@ -13,13 +14,15 @@ func NewApp() *cli.Command {
 	return &cli.Command{
 		Name:  "litecore-cli",
 		Usage: "LiteCore-Go 框架命令行工具",
-		Description: `LiteCore-CLI 是LiteCore配套的命令行工具，提供代码生成和项目脚手架功能。
+		Description: `LiteCore-CLI 是LiteCore配套的命令行工具，提供代码生成、项目脚手架和依赖图导出功能。
 
 代码生成：自动扫描项目并生成依赖注入容器代码
-项目脚手架：快速创建符合 LiteCore 架构的新项目`,
+项目脚手架：快速创建符合 LiteCore 架构的新项目
+依赖图：导出应用的跨层依赖图（DOT、Mermaid、JSON）`,
 		Commands: []*cli.Command{
 			generate.GetCommand(),
 			scaffold.GetCommand(),
+			graph.GetCommand(),
 			GetVersionCommand(),
 			GetCompletionCommand(),
 		},
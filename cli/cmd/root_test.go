@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/lite-lake/litecore-go/cli/cmd/generate"
+	"github.com/lite-lake/litecore-go/cli/cmd/graph"
 	"github.com/lite-lake/litecore-go/cli/cmd/scaffold"
 )
 
@@ -34,6 +35,7 @@ func TestNewApp_Commands(t *testing.T) {
 		expectedCommands := []string{
 			generate.GetCommand().Name,
 			scaffold.GetCommand().Name,
+			graph.GetCommand().Name,
 			GetVersionCommand().Name,
 			GetCompletionCommand().Name,
 		}
@@ -255,6 +255,7 @@ func (b *Builder) convertComponents(components []*analyzer.ComponentInfo) []Comp
 			PackagePath:   comp.PackagePath,
 			PackageAlias:  packageAlias,
 			FactoryFunc:   comp.FactoryFunc,
+			Constructor:   comp.Constructor,
 			Layer:         string(comp.Layer),
 		})
 	}
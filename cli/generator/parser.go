@@ -5,10 +5,12 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/lite-lake/litecore-go/cli/analyzer"
 )
@@ -164,6 +166,7 @@ func (p *Parser) parseRepositoryFile(filename string) error {
 	packagePath := p.getPackagePath(filename)
 	componentMap := make(map[string]*analyzer.ComponentInfo)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if strings.HasPrefix(typeSpec.Name.Name, "I") {
@@ -183,12 +186,21 @@ func (p *Parser) parseRepositoryFile(filename string) error {
 				interfaceName := strings.TrimPrefix(fn.Name.Name, "New")
 				if comp, exists := componentMap["I"+interfaceName]; exists && comp.FactoryFunc == "" {
 					comp.FactoryFunc = fn.Name.Name
+					constructor, err := isConstructor(fset, node, fn)
+					if err != nil {
+						inspectErr = err
+						return false
+					}
+					comp.Constructor = constructor
 				}
 			}
 		}
 
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	for _, comp := range componentMap {
 		p.info.Layers[analyzer.LayerRepository] = append(p.info.Layers[analyzer.LayerRepository], comp)
@@ -230,6 +242,7 @@ func (p *Parser) parseServiceFile(filename string) error {
 	packagePath := p.getPackagePath(filename)
 	componentMap := make(map[string]*analyzer.ComponentInfo)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if strings.HasPrefix(typeSpec.Name.Name, "I") {
@@ -249,12 +262,21 @@ func (p *Parser) parseServiceFile(filename string) error {
 				interfaceName := strings.TrimPrefix(fn.Name.Name, "New")
 				if comp, exists := componentMap["I"+interfaceName]; exists && comp.FactoryFunc == "" {
 					comp.FactoryFunc = fn.Name.Name
+					constructor, err := isConstructor(fset, node, fn)
+					if err != nil {
+						inspectErr = err
+						return false
+					}
+					comp.Constructor = constructor
 				}
 			}
 		}
 
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	for _, comp := range componentMap {
 		p.info.Layers[analyzer.LayerService] = append(p.info.Layers[analyzer.LayerService], comp)
@@ -296,6 +318,7 @@ func (p *Parser) parseControllerFile(filename string) error {
 	packagePath := p.getPackagePath(filename)
 	componentMap := make(map[string]*analyzer.ComponentInfo)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if strings.HasPrefix(typeSpec.Name.Name, "I") {
@@ -315,12 +338,21 @@ func (p *Parser) parseControllerFile(filename string) error {
 				interfaceName := strings.TrimPrefix(fn.Name.Name, "New")
 				if comp, exists := componentMap["I"+interfaceName]; exists && comp.FactoryFunc == "" {
 					comp.FactoryFunc = fn.Name.Name
+					constructor, err := isConstructor(fset, node, fn)
+					if err != nil {
+						inspectErr = err
+						return false
+					}
+					comp.Constructor = constructor
 				}
 			}
 		}
 
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	for _, comp := range componentMap {
 		p.info.Layers[analyzer.LayerController] = append(p.info.Layers[analyzer.LayerController], comp)
@@ -383,6 +415,7 @@ func (p *Parser) parseMiddlewareFile(filename string) error {
 	packagePath := p.getPackagePath(filename)
 	componentMap := make(map[string]*analyzer.ComponentInfo)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if strings.HasPrefix(typeSpec.Name.Name, "I") {
@@ -402,12 +435,21 @@ func (p *Parser) parseMiddlewareFile(filename string) error {
 				interfaceName := strings.TrimPrefix(fn.Name.Name, "New")
 				if comp, exists := componentMap["I"+interfaceName]; exists && comp.FactoryFunc == "" {
 					comp.FactoryFunc = fn.Name.Name
+					constructor, err := isConstructor(fset, node, fn)
+					if err != nil {
+						inspectErr = err
+						return false
+					}
+					comp.Constructor = constructor
 				}
 			}
 		}
 
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	for _, comp := range componentMap {
 		p.info.Layers[analyzer.LayerMiddleware] = append(p.info.Layers[analyzer.LayerMiddleware], comp)
@@ -428,6 +470,7 @@ func (p *Parser) parseListenerFile(filename string) error {
 	packagePath := p.getPackagePath(filename)
 	componentMap := make(map[string]*analyzer.ComponentInfo)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if strings.HasPrefix(typeSpec.Name.Name, "I") {
@@ -447,12 +490,21 @@ func (p *Parser) parseListenerFile(filename string) error {
 				interfaceName := strings.TrimPrefix(fn.Name.Name, "New")
 				if comp, exists := componentMap["I"+interfaceName]; exists && comp.FactoryFunc == "" {
 					comp.FactoryFunc = fn.Name.Name
+					constructor, err := isConstructor(fset, node, fn)
+					if err != nil {
+						inspectErr = err
+						return false
+					}
+					comp.Constructor = constructor
 				}
 			}
 		}
 
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	for _, comp := range componentMap {
 		p.info.Layers[analyzer.LayerListener] = append(p.info.Layers[analyzer.LayerListener], comp)
@@ -494,6 +546,7 @@ func (p *Parser) parseSchedulerFile(filename string) error {
 	packagePath := p.getPackagePath(filename)
 	componentMap := make(map[string]*analyzer.ComponentInfo)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if typeSpec, ok := n.(*ast.TypeSpec); ok {
 			if strings.HasPrefix(typeSpec.Name.Name, "I") {
@@ -513,12 +566,21 @@ func (p *Parser) parseSchedulerFile(filename string) error {
 				interfaceName := strings.TrimPrefix(fn.Name.Name, "New")
 				if comp, exists := componentMap["I"+interfaceName]; exists && comp.FactoryFunc == "" {
 					comp.FactoryFunc = fn.Name.Name
+					constructor, err := isConstructor(fset, node, fn)
+					if err != nil {
+						inspectErr = err
+						return false
+					}
+					comp.Constructor = constructor
 				}
 			}
 		}
 
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	for _, comp := range componentMap {
 		p.info.Layers[analyzer.LayerScheduler] = append(p.info.Layers[analyzer.LayerScheduler], comp)
@@ -560,6 +622,7 @@ func (p *Parser) parseInfrasFile(filename string) error {
 	pkgName := node.Name.Name
 	packagePath := p.getPackagePath(filename)
 
+	var inspectErr error
 	ast.Inspect(node, func(n ast.Node) bool {
 		if fn, ok := n.(*ast.FuncDecl); ok {
 			if !strings.HasPrefix(fn.Name.Name, "New") || fn.Type.Results == nil {
@@ -586,12 +649,19 @@ func (p *Parser) parseInfrasFile(filename string) error {
 				}
 			}
 
+			constructor, err := isConstructor(fset, node, fn)
+			if err != nil {
+				inspectErr = err
+				return false
+			}
+
 			comp := &analyzer.ComponentInfo{
 				InterfaceName: interfaceName,
 				InterfaceType: interfaceType,
 				PackagePath:   packagePath,
 				FileName:      filename,
 				FactoryFunc:   fn.Name.Name,
+				Constructor:   constructor,
 				Layer:         analyzer.LayerService,
 			}
 
@@ -599,6 +669,9 @@ func (p *Parser) parseInfrasFile(filename string) error {
 		}
 		return true
 	})
+	if inspectErr != nil {
+		return inspectErr
+	}
 
 	return nil
 }
@@ -655,3 +728,83 @@ func FindModuleName(projectPath string) (string, error) {
 
 	return strings.TrimSpace(matches[1]), nil
 }
+
+// isConstructor 判断工厂函数是否按构造函数注册（带参数）
+// 构造函数的参数由容器按类型注入，必须均为接口、接口的切片、以 string 为键的接口 map 或 container.Lazy，
+// 否则容器注册时才会失败，因此在生成阶段直接报错
+func isConstructor(fset *token.FileSet, node *ast.File, fn *ast.FuncDecl) (bool, error) {
+	if fn.Type.Params.NumFields() == 0 {
+		return false, nil
+	}
+	interfaces := declaredInterfaces(node)
+	for _, field := range fn.Type.Params.List {
+		if isInjectableParamType(field.Type, interfaces) {
+			continue
+		}
+		name := "_"
+		if len(field.Names) > 0 {
+			name = field.Names[0].Name
+		}
+		return false, fmt.Errorf("%s: factory %s parameter %s has type %s, want an interface (or slice/map of interfaces)",
+			fset.Position(field.Pos()), fn.Name.Name, name, types.ExprString(field.Type))
+	}
+	return true, nil
+}
+
+// declaredInterfaces 返回文件中声明的类型名称及其是否为接口
+func declaredInterfaces(node *ast.File) map[string]bool {
+	declared := make(map[string]bool)
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			_, isInterface := typeSpec.Type.(*ast.InterfaceType)
+			declared[typeSpec.Name.Name] = isInterface
+		}
+	}
+	return declared
+}
+
+// isInjectableParamType 判断参数类型能否由容器注入
+// 当前文件声明的类型按声明判断，其他类型按接口命名约定（I 开头，如 IUserService、loggermgr.ILoggerManager）判断
+func isInjectableParamType(expr ast.Expr, interfaces map[string]bool) bool {
+	switch t := expr.(type) {
+	case *ast.InterfaceType:
+		return true
+	case *ast.Ident:
+		if isInterface, ok := interfaces[t.Name]; ok {
+			return isInterface
+		}
+		return isInterfaceName(t.Name)
+	case *ast.SelectorExpr:
+		return isInterfaceName(t.Sel.Name)
+	case *ast.ArrayType:
+		return t.Len == nil && isInjectableParamType(t.Elt, interfaces)
+	case *ast.MapType:
+		key, ok := t.Key.(*ast.Ident)
+		return ok && key.Name == "string" && isInjectableParamType(t.Value, interfaces)
+	case *ast.IndexExpr:
+		return isLazyType(t.X) && isInjectableParamType(t.Index, interfaces)
+	}
+	return false
+}
+
+// isLazyType 判断是否为 container.Lazy 泛型类型
+func isLazyType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name == "Lazy"
+	case *ast.SelectorExpr:
+		return t.Sel.Name == "Lazy"
+	}
+	return false
+}
+
+// isInterfaceName 判断类型名是否符合接口命名约定（I 后接大写字母）
+func isInterfaceName(name string) bool {
+	runes := []rune(name)
+	return len(runes) > 1 && runes[0] == 'I' && unicode.IsUpper(runes[1])
+}
@@ -46,6 +46,70 @@ func TestRun(t *testing.T) {
 		assert.FileExists(t, filepath.Join(outputDir, "engine.go"))
 	})
 
+	t.Run("构造函数参数为接口_生成构造函数注册", func(t *testing.T) {
+		tempDir := t.TempDir()
+		outputDir := filepath.Join(tempDir, "output")
+		setupRunTestProject(t, tempDir)
+		writeRunTestService(t, tempDir, "order.go", `package services
+
+import "test.module/internal/repositories"
+
+type IOrderService interface {
+	Place() error
+}
+
+func NewOrderService(repo repositories.IUserRepository, notifiers []INotifier) IOrderService {
+	return &OrderService{}
+}
+
+type INotifier interface {
+	Notify() error
+}
+
+type OrderService struct{}
+
+func (s *OrderService) Place() error {
+	return nil
+}
+`)
+
+		err := Run(&Config{ProjectPath: tempDir, OutputDir: outputDir, PackageName: "app", ConfigPath: "configs/config.yaml"})
+		require.NoError(t, err)
+
+		code, err := os.ReadFile(filepath.Join(outputDir, "service_container.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "if err := container.RegisterServiceConstructor[services.IOrderService](serviceContainer, services.NewOrderService); err != nil {")
+	})
+
+	t.Run("构造函数参数非接口_返回错误", func(t *testing.T) {
+		tempDir := t.TempDir()
+		setupRunTestProject(t, tempDir)
+		writeRunTestService(t, tempDir, "order.go", `package services
+
+type IOrderService interface {
+	Place() error
+}
+
+type Config struct {
+	Limit int
+}
+
+func NewOrderService(cfg Config) IOrderService {
+	return &OrderService{}
+}
+
+type OrderService struct{}
+
+func (s *OrderService) Place() error {
+	return nil
+}
+`)
+
+		err := Run(&Config{ProjectPath: tempDir, OutputDir: filepath.Join(tempDir, "output"), PackageName: "app", ConfigPath: "configs/config.yaml"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "factory NewOrderService parameter cfg has type Config, want an interface")
+	})
+
 	t.Run("项目路径无效", func(t *testing.T) {
 		cfg := &Config{
 			ProjectPath: "\x00invalid",
@@ -228,3 +292,10 @@ func (m *AuthMiddleware) Process() bool {
 `
 	os.WriteFile(filepath.Join(tempDir, "internal", "middlewares", "auth.go"), []byte(middlewareCode), 0644)
 }
+
+func writeRunTestService(t *testing.T, tempDir, name, code string) {
+	t.Helper()
+
+	err := os.WriteFile(filepath.Join(tempDir, "internal", "services", name), []byte(code), 0644)
+	require.NoError(t, err)
+}
@@ -113,6 +113,7 @@ func (s *Scanner) extractFactoryFunc(comp *analyzer.ComponentInfo, node *ast.Fil
 		if fn, ok := n.(*ast.FuncDecl); ok {
 			if fn.Name.Name == "New"+strings.TrimPrefix(comp.InterfaceName, "I") {
 				comp.FactoryFunc = fn.Name.Name
+				comp.Constructor = fn.Type.Params.NumFields() > 0
 			}
 		}
 		return true
@@ -66,8 +66,14 @@ func InitRepositoryContainer(entityContainer *container.EntityContainer) *contai
 	repositoryContainer := container.NewRepositoryContainer(entityContainer)
 
 	{{- range .Components}}
+	{{- if .Constructor}}
+	if err := container.RegisterRepositoryConstructor[{{.InterfaceType}}](repositoryContainer, {{.PackageAlias}}.{{.FactoryFunc}}); err != nil {
+		panic(err)
+	}
+	{{- else}}
 	container.RegisterRepository[{{.InterfaceType}}](repositoryContainer, {{.PackageAlias}}.{{.FactoryFunc}}())
 	{{- end}}
+	{{- end}}
 
 	return repositoryContainer
 }
@@ -98,8 +104,14 @@ func InitServiceContainer(repositoryContainer *container.RepositoryContainer) *c
 	serviceContainer := container.NewServiceContainer(repositoryContainer)
 
 	{{- range .Components}}
+	{{- if .Constructor}}
+	if err := container.RegisterServiceConstructor[{{.InterfaceType}}](serviceContainer, {{.PackageAlias}}.{{.FactoryFunc}}); err != nil {
+		panic(err)
+	}
+	{{- else}}
 	container.RegisterService[{{.InterfaceType}}](serviceContainer, {{.PackageAlias}}.{{.FactoryFunc}}())
 	{{- end}}
+	{{- end}}
 
 	return serviceContainer
 }
@@ -130,8 +142,14 @@ func InitControllerContainer(serviceContainer *container.ServiceContainer) *cont
 	controllerContainer := container.NewControllerContainer(serviceContainer)
 
 	{{- range .Components}}
+	{{- if .Constructor}}
+	if err := container.RegisterControllerConstructor[{{.InterfaceType}}](controllerContainer, {{.PackageAlias}}.{{.FactoryFunc}}); err != nil {
+		panic(err)
+	}
+	{{- else}}
 	container.RegisterController[{{.InterfaceType}}](controllerContainer, {{.PackageAlias}}.{{.FactoryFunc}}())
 	{{- end}}
+	{{- end}}
 
 	return controllerContainer
 }
@@ -162,8 +180,14 @@ func InitMiddlewareContainer(serviceContainer *container.ServiceContainer) *cont
 	middlewareContainer := container.NewMiddlewareContainer(serviceContainer)
 
 	{{- range .Components}}
+	{{- if .Constructor}}
+	if err := container.RegisterMiddlewareConstructor[{{.InterfaceType}}](middlewareContainer, {{.PackageAlias}}.{{.FactoryFunc}}); err != nil {
+		panic(err)
+	}
+	{{- else}}
 	container.RegisterMiddleware[{{.InterfaceType}}](middlewareContainer, {{.PackageAlias}}.{{.FactoryFunc}}())
 	{{- end}}
+	{{- end}}
 
 	return middlewareContainer
 }
@@ -194,8 +218,14 @@ func InitListenerContainer(serviceContainer *container.ServiceContainer) *contai
 	listenerContainer := container.NewListenerContainer(serviceContainer)
 
 	{{- range .Components}}
+	{{- if .Constructor}}
+	if err := container.RegisterListenerConstructor[{{.InterfaceType}}](listenerContainer, {{.PackageAlias}}.{{.FactoryFunc}}); err != nil {
+		panic(err)
+	}
+	{{- else}}
 	container.RegisterListener[{{.InterfaceType}}](listenerContainer, {{.PackageAlias}}.{{.FactoryFunc}}())
 	{{- end}}
+	{{- end}}
 
 	return listenerContainer
 }
@@ -226,8 +256,14 @@ func InitSchedulerContainer(serviceContainer *container.ServiceContainer) *conta
 	schedulerContainer := container.NewSchedulerContainer(serviceContainer)
 
 	{{- range .Components}}
+	{{- if .Constructor}}
+	if err := container.RegisterSchedulerConstructor[{{.InterfaceType}}](schedulerContainer, {{.PackageAlias}}.{{.FactoryFunc}}); err != nil {
+		panic(err)
+	}
+	{{- else}}
 	container.RegisterScheduler[{{.InterfaceType}}](schedulerContainer, {{.PackageAlias}}.{{.FactoryFunc}}())
 	{{- end}}
+	{{- end}}
 
 	return schedulerContainer
 }
@@ -315,6 +351,7 @@ type ComponentTemplateData struct {
 	PackagePath   string
 	PackageAlias  string
 	FactoryFunc   string
+	Constructor   bool // 工厂函数带参数，生成 Register*Constructor 注册（构造函数无效时生成代码 panic）
 	Layer         string
 }
 
@@ -126,6 +126,27 @@ func TestGenerateServiceContainer(t *testing.T) {
 	assert.Contains(t, code, "IMessageService")
 }
 
+func TestGenerateServiceContainer_Constructor(t *testing.T) {
+	data := &TemplateData{
+		PackageName: "application",
+		Imports:     []ImportEntry{},
+		Components: []ComponentTemplateData{
+			{
+				InterfaceType: "services.IMessageService",
+				PackagePath:   "github.com/lite-lake/litecore-go/services",
+				PackageAlias:  "services",
+				FactoryFunc:   "NewMessageService",
+				Constructor:   true,
+			},
+		},
+	}
+
+	code, err := GenerateServiceContainer(data)
+	assert.NoError(t, err)
+	assert.Contains(t, code, "if err := container.RegisterServiceConstructor[services.IMessageService](serviceContainer, services.NewMessageService); err != nil {\n\t\tpanic(err)\n\t}")
+	assert.NotContains(t, code, "NewMessageService()")
+}
+
 func TestGenerateControllerContainer(t *testing.T) {
 	data := &TemplateData{
 		PackageName: "application",
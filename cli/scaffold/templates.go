@@ -141,7 +141,6 @@ server:
   read_timeout: "10s"                            # 读取超时时间
   write_timeout: "10s"                           # 写入超时时间
   idle_timeout: "60s"                           # 空闲超时时间
-  enable_recovery: true                         # 是否启用panic恢复
   shutdown_timeout: "30s"                       # 优雅关闭超时时间
   startup_log:                                  # 启动日志配置
     enabled: true                               # 是否启用启动日志
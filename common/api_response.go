@@ -10,8 +10,9 @@ type APIResponse struct {
 
 // APIErrorResponse 统一错误响应结构体（无 Data 字段）
 type APIErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"` // 错误详情（如字段校验错误），可选
 }
 
 // PaginatedData 分页响应数据
@@ -35,13 +36,16 @@ const (
 	CodeSuccess BusinessErrorCode = 200
 
 	// 客户端错误 4xx
-	CodeBadRequest      BusinessErrorCode = 400 // 请求参数错误
-	CodeUnauthorized    BusinessErrorCode = 401 // 未认证
-	CodeForbidden       BusinessErrorCode = 403 // 无权限
-	CodeNotFound        BusinessErrorCode = 404 // 资源不存在
-	CodeConflict        BusinessErrorCode = 409 // 资源冲突
-	CodeUnprocessable   BusinessErrorCode = 422 // 无法处理
-	CodeTooManyRequests BusinessErrorCode = 429 // 请求过于频繁
+	CodeBadRequest       BusinessErrorCode = 400 // 请求参数错误
+	CodeUnauthorized     BusinessErrorCode = 401 // 未认证
+	CodeForbidden        BusinessErrorCode = 403 // 无权限
+	CodeNotFound         BusinessErrorCode = 404 // 资源不存在
+	CodeMethodNotAllowed BusinessErrorCode = 405 // 请求方法不允许
+	CodeRequestTimeout   BusinessErrorCode = 408 // 请求处理超时
+	CodeConflict         BusinessErrorCode = 409 // 资源冲突
+	CodePayloadTooLarge  BusinessErrorCode = 413 // 请求体过大
+	CodeUnprocessable    BusinessErrorCode = 422 // 无法处理
+	CodeTooManyRequests  BusinessErrorCode = 429 // 请求过于频繁
 
 	// 服务端错误 5xx
 	CodeInternalError  BusinessErrorCode = 500 // 内部服务器错误
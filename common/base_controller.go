@@ -1,6 +1,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,3 +19,40 @@ type IBaseController interface {
 	// Handle 处理当前控制器的请求
 	Handle(ctx *gin.Context)
 }
+
+// IRouteGroupController 路由分组控制器接口（可选）
+// 控制器实现此接口后，GetRouter 中声明的路径将挂载到 RouteGroup 返回的分组前缀下，
+// 作用于该分组的中间件（见 IScopedMiddleware）会自动应用到这些路由。
+type IRouteGroupController interface {
+	IBaseController
+	// RouteGroup 返回路由分组前缀，如 "/api/admin"
+	RouteGroup() string
+}
+
+// IAdminController 管理端控制器接口（可选）
+// 控制器实现此接口且 AdminOnly 返回 true 时，启用 server.admin 后路由挂载到独立的管理端监听器，
+// 不会暴露在公共端口上；未启用管理端监听器时仍挂载到公共端口。
+type IAdminController interface {
+	IBaseController
+	// AdminOnly 返回是否仅挂载到管理端监听器
+	AdminOnly() bool
+}
+
+// IRequestTimeoutController 请求超时控制器接口（可选）
+// 控制器实现此接口后，其路由的请求 context 带有 RequestTimeout 的 deadline，
+// 服务层应通过 ctx.Request.Context() 传递给下游（如 GORM 的 WithContext）；
+// 超时后处理函数未写出响应时，Engine 返回 408 APIErrorResponse。
+type IRequestTimeoutController interface {
+	IBaseController
+	// RequestTimeout 返回处理请求的超时时间，覆盖 server.request_timeout，0 表示不限制
+	RequestTimeout() time.Duration
+}
+
+// IBodyLimitController 请求体大小限制控制器接口（可选）
+// 控制器实现此接口后，其路由的请求体超过 MaxBodyBytes 时被拒绝，
+// Engine 返回 413 APIErrorResponse。
+type IBodyLimitController interface {
+	IBaseController
+	// MaxBodyBytes 返回请求体最大字节数，覆盖 server.max_body_bytes，0 表示不限制
+	MaxBodyBytes() int64
+}
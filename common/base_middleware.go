@@ -20,3 +20,26 @@ type IBaseMiddleware interface {
 	// OnStop 在服务器停止时触发
 	OnStop() error
 }
+
+// MiddlewareScope 中间件作用范围
+// Groups 与 Paths 满足任意一项即视为命中；两者均为空时中间件按全局方式注册。
+type MiddlewareScope struct {
+	// Groups 作用的路由分组前缀，与 IRouteGroupController.RouteGroup 精确匹配
+	Groups []string
+	// Paths 作用的路径模式，匹配路由完整路径
+	// 支持 path.Match 通配语法（如 "/api/*/orders"），以 "/**" 结尾表示前缀匹配（如 "/api/admin/**"）
+	Paths []string
+}
+
+// IsGlobal 返回作用范围是否为全局
+func (s MiddlewareScope) IsGlobal() bool {
+	return len(s.Groups) == 0 && len(s.Paths) == 0
+}
+
+// IScopedMiddleware 限定作用范围的中间件接口（可选）
+// 中间件实现此接口后，仅作用于声明的路由分组或路径模式，而非全局注册。
+type IScopedMiddleware interface {
+	IBaseMiddleware
+	// MiddlewareScope 返回中间件作用范围
+	MiddlewareScope() MiddlewareScope
+}
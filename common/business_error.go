@@ -0,0 +1,116 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BusinessError 业务错误
+// 处理函数通过 ctx.Error(err) 提交后，由引擎的错误处理中间件统一渲染为 APIErrorResponse
+type BusinessError struct {
+	Code    BusinessErrorCode // 业务错误码
+	Status  int               // HTTP 状态码
+	Message string            // 返回给客户端的错误消息
+	Details interface{}       // 错误详情（可选），原样输出到响应的 details 字段
+	Cause   error             // 底层错误（可选），仅用于日志，不返回给客户端
+}
+
+// NewBusinessError 创建业务错误，HTTP 状态码由业务错误码推导
+func NewBusinessError(code BusinessErrorCode, message string) *BusinessError {
+	return &BusinessError{
+		Code:    code,
+		Status:  code.HTTPStatus(),
+		Message: message,
+	}
+}
+
+// NewBadRequest 创建参数错误
+func NewBadRequest(message string) *BusinessError {
+	return NewBusinessError(CodeBadRequest, message)
+}
+
+// NewUnauthorized 创建未认证错误
+func NewUnauthorized(message string) *BusinessError {
+	return NewBusinessError(CodeUnauthorized, message)
+}
+
+// NewForbidden 创建无权限错误
+func NewForbidden(message string) *BusinessError {
+	return NewBusinessError(CodeForbidden, message)
+}
+
+// NewNotFound 创建资源不存在错误
+func NewNotFound(message string) *BusinessError {
+	return NewBusinessError(CodeNotFound, message)
+}
+
+// NewConflict 创建资源冲突错误
+func NewConflict(message string) *BusinessError {
+	return NewBusinessError(CodeConflict, message)
+}
+
+// NewInternal 创建内部错误
+func NewInternal(message string) *BusinessError {
+	return NewBusinessError(CodeInternalError, message)
+}
+
+// Error 实现 error 接口，包含底层错误信息
+func (e *BusinessError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+// Unwrap 返回底层错误，支持 errors.Is / errors.As
+func (e *BusinessError) Unwrap() error {
+	return e.Cause
+}
+
+// Wrap 返回附带底层错误的副本
+func (e *BusinessError) Wrap(cause error) *BusinessError {
+	clone := *e
+	clone.Cause = cause
+	return &clone
+}
+
+// WithDetails 返回附带错误详情的副本
+func (e *BusinessError) WithDetails(details interface{}) *BusinessError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// WithStatus 返回指定 HTTP 状态码的副本
+func (e *BusinessError) WithStatus(status int) *BusinessError {
+	clone := *e
+	clone.Status = status
+	return &clone
+}
+
+// Response 转换为统一错误响应
+func (e *BusinessError) Response() *APIErrorResponse {
+	return &APIErrorResponse{
+		Code:    int(e.Code),
+		Message: e.Message,
+		Details: e.Details,
+	}
+}
+
+// AsBusinessError 从错误链中提取业务错误
+func AsBusinessError(err error) (*BusinessError, bool) {
+	var bizErr *BusinessError
+	if errors.As(err, &bizErr) {
+		return bizErr, true
+	}
+	return nil, false
+}
+
+// HTTPStatus 返回业务错误码对应的 HTTP 状态码
+// 业务错误码与 HTTP 状态码一致，超出 400-599 范围的错误码按 500 处理
+func (c BusinessErrorCode) HTTPStatus() int {
+	if c >= 400 && c <= 599 {
+		return int(c)
+	}
+	return HTTPStatusInternalServerError
+}
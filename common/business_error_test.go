@@ -0,0 +1,61 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestBusinessError 测试业务错误
+func TestBusinessError(t *testing.T) {
+	cause := errors.New("duplicate key")
+
+	t.Run("状态码由业务码推导", func(t *testing.T) {
+		tests := []struct {
+			code BusinessErrorCode
+			want int
+		}{
+			{CodeNotFound, 404},
+			{CodeTooManyRequests, 429},
+			{CodeGatewayTimeout, 504},
+			{BusinessErrorCode(10001), 500},
+		}
+		for _, tt := range tests {
+			if got := NewBusinessError(tt.code, "x").Status; got != tt.want {
+				t.Errorf("code=%d 期望状态码 %d, 实际 %d", tt.code, tt.want, got)
+			}
+		}
+	})
+
+	t.Run("Wrap_保留底层错误且不修改原错误", func(t *testing.T) {
+		base := NewConflict("user exists")
+		err := base.Wrap(cause)
+		if !errors.Is(err, cause) {
+			t.Error("期望 errors.Is 匹配底层错误")
+		}
+		if err.Error() != "user exists: duplicate key" {
+			t.Errorf("Error() 不正确: %s", err.Error())
+		}
+		if base.Cause != nil {
+			t.Error("Wrap 不应修改原错误")
+		}
+	})
+
+	t.Run("AsBusinessError_从错误链提取", func(t *testing.T) {
+		wrapped := fmt.Errorf("create user: %w", NewForbidden("denied"))
+		bizErr, ok := AsBusinessError(wrapped)
+		if !ok || bizErr.Code != CodeForbidden {
+			t.Fatalf("提取失败: %v %v", bizErr, ok)
+		}
+		if _, ok := AsBusinessError(cause); ok {
+			t.Error("普通错误不应提取为业务错误")
+		}
+	})
+
+	t.Run("Response_不包含底层错误", func(t *testing.T) {
+		resp := NewBadRequest("invalid").WithDetails(map[string]int{"max": 3}).WithStatus(422).Wrap(cause).Response()
+		if resp.Code != int(CodeBadRequest) || resp.Message != "invalid" || resp.Details == nil {
+			t.Errorf("响应不正确: %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKeyClientCertIdentity gin 上下文中保存 mTLS 客户端证书身份的键
+const ContextKeyClientCertIdentity = "litecore.client_cert_identity"
+
+// ClientCertIdentity mTLS 客户端证书身份
+// 由 Engine 在双向 TLS 握手校验通过后写入 gin 上下文，控制器通过 GetClientCertIdentity 读取
+type ClientCertIdentity struct {
+	CommonName     string    `json:"commonName"`     // 主题通用名称（CN）
+	Organization   []string  `json:"organization"`   // 主题组织（O）
+	DNSNames       []string  `json:"dnsNames"`       // SAN 中的 DNS 名称
+	EmailAddresses []string  `json:"emailAddresses"` // SAN 中的邮箱地址
+	URIs           []string  `json:"uris"`           // SAN 中的 URI（如 SPIFFE ID）
+	SerialNumber   string    `json:"serialNumber"`   // 证书序列号（十进制）
+	Issuer         string    `json:"issuer"`         // 签发者 DN
+	NotAfter       time.Time `json:"notAfter"`       // 证书过期时间
+}
+
+// NewClientCertIdentity 从 X.509 证书提取客户端身份
+func NewClientCertIdentity(cert *x509.Certificate) *ClientCertIdentity {
+	if cert == nil {
+		return nil
+	}
+
+	uris := make([]string, 0, len(cert.URIs))
+	for _, u := range cert.URIs {
+		uris = append(uris, u.String())
+	}
+
+	return &ClientCertIdentity{
+		CommonName:     cert.Subject.CommonName,
+		Organization:   cert.Subject.Organization,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		URIs:           uris,
+		SerialNumber:   cert.SerialNumber.String(),
+		Issuer:         cert.Issuer.String(),
+		NotAfter:       cert.NotAfter,
+	}
+}
+
+// GetClientCertIdentity 获取当前请求已校验的 mTLS 客户端证书身份
+// 未启用 mTLS 或客户端未提供经校验的证书时返回 false
+func GetClientCertIdentity(ctx *gin.Context) (*ClientCertIdentity, bool) {
+	value, exists := ctx.Get(ContextKeyClientCertIdentity)
+	if !exists {
+		return nil, false
+	}
+	identity, ok := value.(*ClientCertIdentity)
+	return identity, ok && identity != nil
+}
@@ -0,0 +1,19 @@
+package common
+
+import "context"
+
+// IHealthChecker 健康检查接口（可选）
+// Service、Repository 实现此接口后由 Engine 自动发现，检查结果出现在 /api/ready 和 HealthController 的输出中，
+// 适用于持有外部客户端（第三方 HTTP API、对象存储等）的组件。
+// 检查项名称为 ServiceName/RepositoryName，默认为非关键检查，可通过 server.readiness.critical 标记为关键。
+type IHealthChecker interface {
+	// Health 检查健康状态，返回 nil 表示健康
+	// ctx 携带检查超时，实现应在 ctx 取消时尽快返回
+	Health(ctx context.Context) error
+}
+
+// IHealthCheckerAware 需要感知所有健康检查者的组件接口（可选）
+// Engine 在注册路由前将自动发现的健康检查者按名称传入实现此接口的控制器
+type IHealthCheckerAware interface {
+	SetHealthCheckers(checkers map[string]IHealthChecker)
+}
@@ -1,8 +1,10 @@
 package litecontroller
 
 import (
+	"context"
 	"github.com/lite-lake/litecore-go/manager/loggermgr"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,11 +14,15 @@ import (
 
 // HealthResponse 健康检查响应
 type HealthResponse struct {
-	Status    string            `json:"status"`
-	Timestamp string            `json:"timestamp"`
-	Managers  map[string]string `json:"managers,omitempty"`
+	Status     string            `json:"status"`
+	Timestamp  string            `json:"timestamp"`
+	Managers   map[string]string `json:"managers,omitempty"`
+	Components map[string]string `json:"components,omitempty"` // 实现 common.IHealthChecker 的 Service/Repository 检查结果
 }
 
+// componentCheckTimeout 单个组件健康检查超时
+const componentCheckTimeout = 3 * time.Second
+
 // IHealthController 健康检查控制器接口
 //
 // Deprecated: 系统路由已由 Engine 自动注册 /api/health（liveness）和 /api/ready（readiness），
@@ -32,6 +38,8 @@ type IHealthController interface {
 type HealthController struct {
 	ManagerContainer common.IBaseManager      `inject:""`
 	LoggerMgr        loggermgr.ILoggerManager `inject:""`
+
+	checkers map[string]common.IHealthChecker // 由 Engine 通过 SetHealthCheckers 传入
 }
 
 // NewHealthController 创建健康检查控制器
@@ -49,6 +57,11 @@ func (c *HealthController) GetRouter() string {
 	return "/api/health [GET]"
 }
 
+// SetHealthCheckers 设置 Engine 自动发现的 Service/Repository 健康检查者
+func (c *HealthController) SetHealthCheckers(checkers map[string]common.IHealthChecker) {
+	c.checkers = checkers
+}
+
 func (c *HealthController) Handle(ctx *gin.Context) {
 	managerStatus := make(map[string]string)
 	allHealthy := true
@@ -64,15 +77,34 @@ func (c *HealthController) Handle(ctx *gin.Context) {
 		}
 	}
 
+	componentStatus := make(map[string]string, len(c.checkers))
+	names := make([]string, 0, len(c.checkers))
+	for name := range c.checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checkCtx, cancel := context.WithTimeout(ctx.Request.Context(), componentCheckTimeout)
+		err := c.checkers[name].Health(checkCtx)
+		cancel()
+		if err != nil {
+			componentStatus[name] = "unhealthy: " + err.Error()
+			allHealthy = false
+		} else {
+			componentStatus[name] = "ok"
+		}
+	}
+
 	status := "ok"
 	if !allHealthy {
 		status = "degraded"
 	}
 
 	response := HealthResponse{
-		Status:    status,
-		Timestamp: time.Now().Format(time.RFC3339),
-		Managers:  managerStatus,
+		Status:     status,
+		Timestamp:  time.Now().Format(time.RFC3339),
+		Managers:   managerStatus,
+		Components: componentStatus,
 	}
 
 	if allHealthy {
@@ -83,3 +115,4 @@ func (c *HealthController) Handle(ctx *gin.Context) {
 }
 
 var _ common.IBaseController = (*HealthController)(nil)
+var _ common.IHealthCheckerAware = (*HealthController)(nil)
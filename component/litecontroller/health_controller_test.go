@@ -1,12 +1,15 @@
 package litecontroller
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lite-lake/litecore-go/common"
 )
 
 type mockManager struct {
@@ -115,3 +118,52 @@ func TestHealthController_Handle_不健康Manager(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"status":"degraded"`)
 	assert.Contains(t, w.Body.String(), `"UnhealthyManager":"unhealthy`)
 }
+
+type mockHealthChecker struct {
+	err error
+}
+
+func (m *mockHealthChecker) Health(ctx context.Context) error {
+	return m.err
+}
+
+func TestHealthController_Handle_组件健康检查(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		checkers     map[string]common.IHealthChecker
+		expectedCode int
+		contains     []string
+	}{
+		{
+			name:         "组件健康",
+			checkers:     map[string]common.IHealthChecker{"PaymentService": &mockHealthChecker{}},
+			expectedCode: http.StatusOK,
+			contains:     []string{`"status":"ok"`, `"PaymentService":"ok"`},
+		},
+		{
+			name:         "组件不健康",
+			checkers:     map[string]common.IHealthChecker{"OSSRepository": &mockHealthChecker{err: assert.AnError}},
+			expectedCode: http.StatusServiceUnavailable,
+			contains:     []string{`"status":"degraded"`, `"OSSRepository":"unhealthy`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			controller := NewHealthController()
+			controller.(common.IHealthCheckerAware).SetHealthCheckers(tt.checkers)
+			engine.GET("/health", controller.Handle)
+
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			for _, s := range tt.contains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -51,4 +51,10 @@ func (c *MetricsController) Handle(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, metrics)
 }
 
+// AdminOnly 指标路由挂载到管理端监听器
+func (c *MetricsController) AdminOnly() bool {
+	return true
+}
+
 var _ common.IBaseController = (*MetricsController)(nil)
+var _ common.IAdminController = (*MetricsController)(nil)
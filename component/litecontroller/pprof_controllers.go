@@ -45,7 +45,13 @@ func (c *PprofController) Handle(ctx *gin.Context) {
 	c.handle(wrapResponseWriter(ctx.Writer), ctx.Request)
 }
 
+// AdminOnly pprof 路由挂载到管理端监听器
+func (c *PprofController) AdminOnly() bool {
+	return true
+}
+
 var _ common.IBaseController = (*PprofController)(nil)
+var _ common.IAdminController = (*PprofController)(nil)
 
 type IPprofIndexController interface {
 	common.IBaseController
@@ -15,9 +15,9 @@ type RecoveryConfig struct {
 	Name            *string // 中间件名称
 	Order           *int    // 执行顺序
 	PrintStack      *bool   // 是否打印堆栈信息
-	CustomErrorBody *bool   // 是否使用自定义错误响应
+	CustomErrorBody *bool   // 是否使用 JSON 错误响应（APIErrorResponse）
 	ErrorMessage    *string // 自定义错误消息
-	ErrorCode       *string // 自定义错误代码
+	ErrorCode       *string // 自定义错误代码（输出到响应 details.error_code）
 }
 
 // DefaultRecoveryConfig 默认 panic 恢复配置
@@ -132,10 +132,9 @@ func (m *recoveryMiddleware) Wrapper() gin.HandlerFunc {
 				m.LoggerMgr.Ins().Error("PANIC recovered - internal error", fields...)
 
 				if m.cfg.CustomErrorBody != nil && *m.cfg.CustomErrorBody {
-					c.JSON(common.HTTPStatusInternalServerError, gin.H{
-						"error": *m.cfg.ErrorMessage,
-						"code":  *m.cfg.ErrorCode,
-					})
+					resp := common.ErrorWith(common.CodeInternalError, *m.cfg.ErrorMessage)
+					resp.Details = gin.H{"error_code": *m.cfg.ErrorCode}
+					c.JSON(common.HTTPStatusInternalServerError, resp)
 				} else {
 					c.String(common.HTTPStatusInternalServerError, *m.cfg.ErrorMessage)
 				}
@@ -12,46 +12,62 @@ type InjectableContainer interface {
 }
 
 // TypedContainer 类型化容器
-// 使用接口类型作为键，存储对应的实现实例
+// 使用接口类型作为键，存储对应的实现实例；每个接口有一个默认实现，另可按名称注册多个具名实现
 type TypedContainer[T any] struct {
 	mu       sync.RWMutex
 	items    map[reflect.Type]T
+	named    map[reflect.Type][]typedEntry[T] // 具名实现，按注册顺序
+	ctors    map[reflect.Type]*constructor    // 构造函数，注入时调用并将返回值作为默认实现
 	nameFunc func(T) string
 	injected bool
 }
 
+// typedEntry 具名实现
+type typedEntry[T any] struct {
+	name string
+	impl T
+}
+
 // NewTypedContainer 创建新的类型化容器
 func NewTypedContainer[T any](nameFunc func(T) string) *TypedContainer[T] {
 	return &TypedContainer[T]{
 		items:    make(map[reflect.Type]T),
+		named:    make(map[reflect.Type][]typedEntry[T]),
+		ctors:    make(map[reflect.Type]*constructor),
 		nameFunc: nameFunc,
 	}
 }
 
-// Register 按接口类型注册实现实例
-func (c *TypedContainer[T]) Register(ifaceType reflect.Type, impl T) error {
+// checkImpl 检查实现实例非 nil 且实现了接口
+func checkImpl[T any](ifaceType reflect.Type, impl T) error {
 	implVal := reflect.ValueOf(impl)
 
 	if !implVal.IsValid() || (implVal.Kind() == reflect.Ptr && implVal.IsNil()) {
 		return &DuplicateRegistrationError{Name: "nil"}
 	}
 
-	implType := implVal.Type()
-
-	if !implType.Implements(ifaceType) {
+	if !implVal.Type().Implements(ifaceType) {
 		return &ImplementationDoesNotImplementInterfaceError{
 			InterfaceType:  ifaceType,
 			Implementation: impl,
 		}
 	}
+	return nil
+}
+
+// Register 按接口类型注册实现实例（默认实现）
+func (c *TypedContainer[T]) Register(ifaceType reflect.Type, impl T) error {
+	if err := checkImpl(ifaceType, impl); err != nil {
+		return err
+	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if _, exists := c.items[ifaceType]; exists {
+	if existing, exists := c.existingDefault(ifaceType); exists {
 		return &InterfaceAlreadyRegisteredError{
 			InterfaceType: ifaceType,
-			ExistingImpl:  c.items[ifaceType],
+			ExistingImpl:  existing,
 			NewImpl:       impl,
 		}
 	}
@@ -60,6 +76,176 @@ func (c *TypedContainer[T]) Register(ifaceType reflect.Type, impl T) error {
 	return nil
 }
 
+// RegisterConstructor 按接口类型注册构造函数，注入时解析参数并调用，返回值作为默认实现
+func (c *TypedContainer[T]) RegisterConstructor(ifaceType reflect.Type, ctor interface{}) error {
+	ctorInfo, err := newConstructor(ifaceType, ctor)
+	if err != nil {
+		return err
+	}
+	if baseType := reflect.TypeOf((*T)(nil)).Elem(); !ctorInfo.fn.Type().Out(0).Implements(baseType) {
+		return &InvalidConstructorError{
+			Constructor: ctorInfo.name,
+			Reason:      fmt.Sprintf("return type %s does not implement %s", ctorInfo.fn.Type().Out(0), baseType),
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, exists := c.existingDefault(ifaceType); exists {
+		return &InterfaceAlreadyRegisteredError{
+			InterfaceType: ifaceType,
+			ExistingImpl:  existing,
+			NewImpl:       ctor,
+		}
+	}
+
+	c.ctors[ifaceType] = ctorInfo
+	return nil
+}
+
+// existingDefault 返回已注册的默认实现或构造函数（调用方需持有锁）
+func (c *TypedContainer[T]) existingDefault(ifaceType reflect.Type) (interface{}, bool) {
+	if impl, exists := c.items[ifaceType]; exists {
+		return impl, true
+	}
+	if ctor, exists := c.ctors[ifaceType]; exists {
+		return ctor.fn.Interface(), true
+	}
+	return nil, false
+}
+
+// constructors 返回所有构造函数（含已调用的），pendingOnly 为 true 时仅返回尚未调用的
+func (c *TypedContainer[T]) constructors(pendingOnly bool) map[reflect.Type]*constructor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make(map[reflect.Type]*constructor, len(c.ctors))
+	for ifaceType, ctor := range c.ctors {
+		if _, built := c.items[ifaceType]; pendingOnly && built {
+			continue
+		}
+		result[ifaceType] = ctor
+	}
+	return result
+}
+
+// constructorParamTypes 返回所有构造函数参数依赖的接口类型
+func (c *TypedContainer[T]) constructorParamTypes() []reflect.Type {
+	var types []reflect.Type
+	for _, ctor := range c.constructors(false) {
+		types = append(types, ctor.paramTypes()...)
+	}
+	return types
+}
+
+// construct 调用构造函数，成功后将返回值注册为默认实现，返回构造函数的 Lazy 参数
+func (c *TypedContainer[T]) construct(ifaceType reflect.Type, ctor *constructor, resolver *GenericDependencyResolver) ([]lazyDependency, error) {
+	instance, lazies, err := ctor.call(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[ifaceType] = instance.(T)
+	return lazies, nil
+}
+
+// RegisterNamed 按接口类型和名称注册具名实现，同一接口可注册多个不同名称的实现
+// 具名实现与默认实现互不冲突，通过 inject:"name=xxx" 或切片、map 字段注入
+func (c *TypedContainer[T]) RegisterNamed(ifaceType reflect.Type, name string, impl T) error {
+	if name == "" {
+		return c.Register(ifaceType, impl)
+	}
+	if err := checkImpl(ifaceType, impl); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range c.named[ifaceType] {
+		if entry.name == name {
+			return &DuplicateRegistrationError{Name: name, Existing: entry.impl, New: impl}
+		}
+	}
+
+	c.named[ifaceType] = append(c.named[ifaceType], typedEntry[T]{name: name, impl: impl})
+	return nil
+}
+
+// GetNamed 按接口类型和名称获取具名实现
+func (c *TypedContainer[T]) GetNamed(ifaceType reflect.Type, name string) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, entry := range c.named[ifaceType] {
+		if entry.name == name {
+			return entry.impl, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// getDefault 获取接口的默认实现
+func (c *TypedContainer[T]) getDefault(ifaceType reflect.Type) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	impl, ok := c.items[ifaceType]
+	return impl, ok
+}
+
+// hasDefault 判断接口是否注册了默认实现或构造函数
+func (c *TypedContainer[T]) hasDefault(ifaceType reflect.Type) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, exists := c.existingDefault(ifaceType)
+	return exists
+}
+
+// namedOf 返回接口的所有具名实现
+func (c *TypedContainer[T]) namedOf(ifaceType reflect.Type) []typedEntry[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]typedEntry[T](nil), c.named[ifaceType]...)
+}
+
+// entriesOf 返回接口的所有实现：默认实现（以组件名称为名）在前，具名实现按注册顺序
+func (c *TypedContainer[T]) entriesOf(ifaceType reflect.Type) []typedEntry[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var entries []typedEntry[T]
+	if impl, ok := c.items[ifaceType]; ok {
+		entries = append(entries, typedEntry[T]{name: c.nameFunc(impl), impl: impl})
+	}
+	return append(entries, c.named[ifaceType]...)
+}
+
+// Replace 替换已注册接口的实现实例
+// 仅允许在依赖注入之前调用（如测试中用 fake 替换真实实现）
+func (c *TypedContainer[T]) Replace(ifaceType reflect.Type, impl T) error {
+	if err := checkImpl(ifaceType, impl); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.injected {
+		return &ReplaceAfterInjectionError{InterfaceType: ifaceType}
+	}
+	if _, exists := c.existingDefault(ifaceType); !exists {
+		return &InterfaceNotRegisteredError{InterfaceType: ifaceType}
+	}
+
+	delete(c.ctors, ifaceType)
+	c.items[ifaceType] = impl
+	return nil
+}
+
 // GetByType 按接口类型获取实现实例
 func (c *TypedContainer[T]) GetByType(ifaceType reflect.Type) T {
 	c.mu.RLock()
@@ -73,7 +259,7 @@ func (c *TypedContainer[T]) GetByType(ifaceType reflect.Type) T {
 	return impl
 }
 
-// GetAll 获取所有已注册的实例
+// GetAll 获取所有已注册的实例（含具名实现）
 func (c *TypedContainer[T]) GetAll() []T {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -82,10 +268,15 @@ func (c *TypedContainer[T]) GetAll() []T {
 	for _, item := range c.items {
 		result = append(result, item)
 	}
+	for _, entries := range c.named {
+		for _, entry := range entries {
+			result = append(result, entry.impl)
+		}
+	}
 	return result
 }
 
-// GetNames 获取所有实例的名称
+// GetNames 获取所有实例的名称（含具名实现）
 func (c *TypedContainer[T]) GetNames() []string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -94,14 +285,28 @@ func (c *TypedContainer[T]) GetNames() []string {
 	for _, item := range c.items {
 		result = append(result, c.nameFunc(item))
 	}
+	for _, entries := range c.named {
+		for _, entry := range entries {
+			result = append(result, c.nameFunc(entry.impl))
+		}
+	}
 	return result
 }
 
-// Count 返回已注册的实例数量
+// Count 返回已注册的实例数量（含具名实现和尚未调用的构造函数）
 func (c *TypedContainer[T]) Count() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return len(c.items)
+	count := len(c.items)
+	for ifaceType := range c.ctors {
+		if _, built := c.items[ifaceType]; !built {
+			count++
+		}
+	}
+	for _, entries := range c.named {
+		count += len(entries)
+	}
+	return count
 }
 
 // IsInjected 返回是否已完成依赖注入
@@ -118,7 +323,7 @@ func (c *TypedContainer[T]) setInjected(injected bool) {
 	c.mu.Unlock()
 }
 
-// RangeItems 遍历所有实例
+// RangeItems 遍历所有默认实现（不含具名实现）
 func (c *TypedContainer[T]) RangeItems(fn func(reflect.Type, T) bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -130,6 +335,25 @@ func (c *TypedContainer[T]) RangeItems(fn func(reflect.Type, T) bool) {
 	}
 }
 
+// rangeEntries 遍历所有实现（含具名实现），默认实现的 name 为空
+func (c *TypedContainer[T]) rangeEntries(fn func(ifaceType reflect.Type, name string, impl T) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for ifaceType, item := range c.items {
+		if !fn(ifaceType, "", item) {
+			return
+		}
+	}
+	for ifaceType, entries := range c.named {
+		for _, entry := range entries {
+			if !fn(ifaceType, entry.name, entry.impl) {
+				return
+			}
+		}
+	}
+}
+
 // NamedContainer 命名容器
 // 使用名称字符串作为键，存储对应的实例
 type NamedContainer[T any] struct {
@@ -227,6 +451,8 @@ func (ic *injectableContainer[T]) buildSources(self ContainerSource, sources ...
 }
 
 // injectAll 执行依赖注入
+// 先调用注册的构造函数，再注入所有实例的 inject 标签字段；
+// 某个实例注入失败时继续注入其余实例，返回所有实例的错误
 func (ic *injectableContainer[T]) injectAll(self ContainerSource) error {
 	if ic.container.IsInjected() {
 		return nil
@@ -234,14 +460,40 @@ func (ic *injectableContainer[T]) injectAll(self ContainerSource) error {
 
 	resolver := NewGenericDependencyResolver(ic.sources...)
 
+	// 先调用构造函数：同层组件之间没有依赖，可按任意顺序构造
+	var lazies []lazyDependency
+	var errs []error
+	for ifaceType, ctor := range ic.container.constructors(true) {
+		ctorLazies, err := ic.container.construct(ifaceType, ctor, resolver)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		lazies = append(lazies, ctorLazies...)
+	}
+
 	items := ic.container.GetAll()
 	for _, item := range items {
-		if err := injectDependencies(item, resolver); err != nil {
-			return err
+		if err := injectInstance(item, resolver); err != nil {
+			errs = append(errs, err)
 		}
-		verifyInjectTags(item)
+		lazies = append(lazies, lazyFields(item)...)
+	}
+	if len(errs) > 0 {
+		return joinErrors(errs)
+	}
+	if err := checkLazyDependencies(lazies); err != nil {
+		return err
 	}
 
 	ic.container.setInjected(true)
 	return nil
 }
+
+// injectInstance 注入单个实例并验证 inject 标签字段均已注入
+// 注入失败时不再验证，避免同一字段重复报错
+func injectInstance(instance interface{}, resolver IDependencyResolver) error {
+	if err := injectDependencies(instance, resolver); err != nil {
+		return err
+	}
+	return verifyInjectTags(instance)
+}
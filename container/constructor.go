@@ -0,0 +1,124 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// constructor 构造函数注册信息
+// 构造函数形如 func(repo IUserRepository, cache cachemgr.ICacheManager) IUserService，
+// 可选返回 error；参数按类型解析，[]I 和 map[string]I 参数注入接口的所有实现
+type constructor struct {
+	fn         reflect.Value
+	name       string            // 函数名，如 services.NewUserService
+	params     []dependencyQuery // 参数依赖
+	returnsErr bool
+}
+
+// newConstructor 校验构造函数签名：返回值为实现 ifaceType 的实例（可附加 error），参数为接口类型或接口的切片、map
+func newConstructor(ifaceType reflect.Type, ctor interface{}) (*constructor, error) {
+	fn := reflect.ValueOf(ctor)
+	if !fn.IsValid() || fn.Kind() != reflect.Func || fn.IsNil() {
+		return nil, &InvalidConstructorError{Constructor: fmt.Sprintf("%T", ctor), Reason: "not a function"}
+	}
+
+	c := &constructor{fn: fn, name: funcName(fn)}
+	fnType := fn.Type()
+	invalid := func(format string, args ...interface{}) error {
+		return &InvalidConstructorError{Constructor: c.name, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	switch {
+	case fnType.NumOut() == 2 && fnType.Out(1) == errorType:
+		c.returnsErr = true
+	case fnType.NumOut() != 1:
+		return nil, invalid("must return (%s) or (%s, error)", ifaceType, ifaceType)
+	}
+	if !fnType.Out(0).Implements(ifaceType) {
+		return nil, invalid("return type %s does not implement %s", fnType.Out(0), ifaceType)
+	}
+	if fnType.IsVariadic() {
+		return nil, invalid("variadic parameters are not supported")
+	}
+
+	for i := 0; i < fnType.NumIn(); i++ {
+		q := newTypeQuery(fnType.In(i))
+		if q.ifaceType.Kind() != reflect.Interface {
+			return nil, invalid("parameter %d has type %s, want an interface", i, fnType.In(i))
+		}
+		c.params = append(c.params, q)
+	}
+	return c, nil
+}
+
+// paramName 返回参数在错误信息中的名称
+func (c *constructor) paramName(i int) string {
+	return fmt.Sprintf("arg%d", i)
+}
+
+// call 解析参数并调用构造函数，参数解析失败时返回所有参数的错误
+// 同时返回 Lazy 参数，供所在层注入完成后检查
+func (c *constructor) call(resolver *GenericDependencyResolver) (interface{}, []lazyDependency, error) {
+	args := make([]reflect.Value, len(c.params))
+	var lazies []lazyDependency
+	var errs []error
+	for i, q := range c.params {
+		arg, err := resolveInjection(resolver, q, nil, c.name, c.paramName(i))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if q.lazy() {
+			lazies = append(lazies, arg.Interface().(lazyDependency))
+		}
+		args[i] = arg
+	}
+	if len(errs) > 0 {
+		return nil, nil, joinErrors(errs)
+	}
+
+	out := c.fn.Call(args)
+	if c.returnsErr && !out[1].IsNil() {
+		return nil, nil, &ConstructorError{Constructor: c.name, Err: out[1].Interface().(error)}
+	}
+	if isNilValue(out[0]) {
+		return nil, nil, &ConstructorError{Constructor: c.name, Err: errors.New("returned nil instance")}
+	}
+	return out[0].Interface(), lazies, nil
+}
+
+// paramTypes 返回参数依赖的接口类型（切片、map 参数返回元素类型）
+func (c *constructor) paramTypes() []reflect.Type {
+	types := make([]reflect.Type, 0, len(c.params))
+	for _, q := range c.params {
+		types = append(types, q.ifaceType)
+	}
+	return types
+}
+
+// isNilValue 判断值是否为 nil（接口、指针等）
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// funcName 返回函数的简短名称，如 services.NewUserService
+func funcName(fn reflect.Value) string {
+	f := runtime.FuncForPC(fn.Pointer())
+	if f == nil {
+		return fn.Type().String()
+	}
+	name := f.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
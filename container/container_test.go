@@ -1,8 +1,15 @@
 package container
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
 )
 
 // 模拟测试接口
@@ -162,6 +169,29 @@ func TestTypedContainer(t *testing.T) {
 			t.Errorf("期望遍历 1 个实例，实际: %d", count)
 		}
 	})
+
+	t.Run("替换已注册实现", func(t *testing.T) {
+		container := NewTypedContainer(func(item testInterface) string { return item.testMethod() })
+		ifaceType := reflect.TypeOf((*testInterface)(nil)).Elem()
+
+		if err := container.Replace(ifaceType, &testImplementation{name: "fake"}); err == nil {
+			t.Error("替换未注册接口应返回错误")
+		}
+
+		container.Register(ifaceType, &testImplementation{name: "real"})
+		if err := container.Replace(ifaceType, &testImplementation{name: "fake"}); err != nil {
+			t.Fatalf("替换失败: %v", err)
+		}
+		if got := container.GetByType(ifaceType).testMethod(); got != "fake" {
+			t.Errorf("期望替换为 fake，实际: %s", got)
+		}
+
+		container.setInjected(true)
+		err := container.Replace(ifaceType, &testImplementation{name: "late"})
+		if _, ok := err.(*ReplaceAfterInjectionError); !ok {
+			t.Errorf("注入后替换应返回 ReplaceAfterInjectionError，实际: %v", err)
+		}
+	})
 }
 
 // 测试 NamedContainer
@@ -592,3 +622,829 @@ func (m *mockContainerSource) GetDependency(fieldType reflect.Type) (interface{}
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && s[:len(substr)] == substr || (len(s) > len(substr) && contains(s[1:], substr))
 }
+
+type testManagerInterface interface {
+	common.IBaseManager
+}
+
+type testManagerConsumer struct {
+	Manager testManagerInterface `inject:""`
+	Name    string
+}
+
+func TestManagerContainerDisabled(t *testing.T) {
+	ifaceType := reflect.TypeOf((*testManagerInterface)(nil)).Elem()
+	managers := NewManagerContainer()
+	managers.MarkDisabled(ifaceType, "mq.enabled is false")
+
+	reason, ok := managers.DisabledReason(ifaceType)
+	if !ok || reason != "mq.enabled is false" {
+		t.Errorf("期望禁用原因 mq.enabled is false，实际: %q", reason)
+	}
+
+	resolver := NewGenericDependencyResolver(managers)
+	err := injectDependencies(&testManagerConsumer{}, resolver)
+	notFound, ok := err.(*DependencyNotFoundError)
+	if !ok {
+		t.Fatalf("期望 DependencyNotFoundError，实际: %v", err)
+	}
+	if notFound.InstanceName != "testManagerConsumer" || notFound.FieldName != "Manager" {
+		t.Errorf("错误未定位到字段: %v", err)
+	}
+	if !contains(err.Error(), "dependency not found") || notFound.Message != "manager is disabled (mq.enabled is false)" {
+		t.Errorf("错误信息未包含禁用原因: %v", err)
+	}
+}
+
+func TestInjectFieldTypes(t *testing.T) {
+	types := InjectFieldTypes(&testManagerConsumer{})
+	if len(types) != 1 || types[0] != reflect.TypeOf((*testManagerInterface)(nil)).Elem() {
+		t.Errorf("期望仅返回 Manager 字段类型，实际: %v", types)
+	}
+	if InjectFieldTypes(nil) != nil || InjectFieldTypes("not a struct") != nil {
+		t.Error("非结构体期望返回 nil")
+	}
+}
+
+type testMissingService interface {
+	common.IBaseService
+}
+
+type testAggregateService interface {
+	common.IBaseService
+}
+
+// testAggregateServiceImpl 同时缺失服务依赖和管理器依赖的测试服务
+type testAggregateServiceImpl struct {
+	Missing testMissingService   `inject:""`
+	Manager testManagerInterface `inject:""`
+}
+
+func (s *testAggregateServiceImpl) ServiceName() string { return "AggregateService" }
+func (s *testAggregateServiceImpl) OnStart() error      { return nil }
+func (s *testAggregateServiceImpl) OnStop() error       { return nil }
+
+func TestInjectAllCollectsErrors(t *testing.T) {
+	t.Run("Service层_返回所有缺失依赖", func(t *testing.T) {
+		services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+		services.SetManagerContainer(NewManagerContainer())
+		_ = RegisterService[testAggregateService](services, &testAggregateServiceImpl{})
+
+		_, graphErr := services.buildDependencyGraph()
+		notFound, ok := graphErr.(*DependencyNotFoundError)
+		if !ok || notFound.InstanceName != "testAggregateServiceImpl" || notFound.FieldName != "Missing" {
+			t.Fatalf("期望依赖图返回定位到字段的 DependencyNotFoundError，实际: %v", graphErr)
+		}
+
+		err := services.InjectAll()
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok || len(joined.Unwrap()) != 2 {
+			t.Fatalf("期望返回 2 个错误，实际: %v", err)
+		}
+		if services.base.container.IsInjected() {
+			t.Error("注入失败时不应标记为已注入")
+		}
+	})
+
+	t.Run("未设置ManagerContainer_返回错误", func(t *testing.T) {
+		controllers := NewControllerContainer(nil)
+		if _, ok := controllers.InjectAll().(*ManagerContainerNotSetError); !ok {
+			t.Error("期望返回 ManagerContainerNotSetError")
+		}
+	})
+
+	t.Run("未注入字段_返回所有字段", func(t *testing.T) {
+		type twoFields struct {
+			A testMissingService   `inject:""`
+			B testManagerInterface `inject:""`
+		}
+		err := verifyInjectTags(&twoFields{})
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok || len(joined.Unwrap()) != 2 {
+			t.Fatalf("期望返回 2 个 UninjectedFieldError，实际: %v", err)
+		}
+	})
+}
+
+type testPaymentGateway interface {
+	common.IBaseService
+	Pay() string
+}
+
+// testGateway 具名注册的支付网关测试服务
+type testGateway struct {
+	name string
+}
+
+func (g *testGateway) ServiceName() string { return g.name }
+func (g *testGateway) OnStart() error      { return nil }
+func (g *testGateway) OnStop() error       { return nil }
+func (g *testGateway) Pay() string         { return g.name }
+
+type testCheckoutService interface {
+	common.IBaseService
+}
+
+// testCheckoutServiceImpl 按名称、切片和 map 注入支付网关的测试服务
+type testCheckoutServiceImpl struct {
+	Stripe testPaymentGateway            `inject:"name=stripe"`
+	All    []testPaymentGateway          `inject:""`
+	ByName map[string]testPaymentGateway `inject:""`
+}
+
+func (s *testCheckoutServiceImpl) ServiceName() string { return "CheckoutService" }
+func (s *testCheckoutServiceImpl) OnStart() error      { return nil }
+func (s *testCheckoutServiceImpl) OnStop() error       { return nil }
+
+// newTestGatewayServices 创建注册了 stripe、paypal 两个具名网关的服务容器
+func newTestGatewayServices(t *testing.T) *ServiceContainer {
+	t.Helper()
+	services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+	services.SetManagerContainer(NewManagerContainer())
+	for _, name := range []string{"stripe", "paypal"} {
+		if err := RegisterServiceNamed[testPaymentGateway](services, name, &testGateway{name: name}); err != nil {
+			t.Fatalf("注册具名服务失败: %v", err)
+		}
+	}
+	return services
+}
+
+func TestNamedInjection(t *testing.T) {
+	t.Run("按名称_切片_map注入", func(t *testing.T) {
+		services := newTestGatewayServices(t)
+		checkout := &testCheckoutServiceImpl{}
+		_ = RegisterService[testCheckoutService](services, checkout)
+
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("注入失败: %v", err)
+		}
+		if checkout.Stripe == nil || checkout.Stripe.Pay() != "stripe" {
+			t.Errorf("期望注入 stripe 网关，实际: %v", checkout.Stripe)
+		}
+		if len(checkout.All) != 2 || checkout.All[0].Pay() != "stripe" || checkout.All[1].Pay() != "paypal" {
+			t.Errorf("切片注入不正确: %v", checkout.All)
+		}
+		if len(checkout.ByName) != 2 || checkout.ByName["paypal"].Pay() != "paypal" {
+			t.Errorf("map 注入不正确: %v", checkout.ByName)
+		}
+	})
+
+	t.Run("拓扑排序_具名服务在依赖方之前", func(t *testing.T) {
+		services := newTestGatewayServices(t)
+		_ = RegisterService[testCheckoutService](services, &testCheckoutServiceImpl{})
+
+		sorted, err := services.GetAllTopological()
+		if err != nil {
+			t.Fatalf("拓扑排序失败: %v", err)
+		}
+		if len(sorted) != 3 || sorted[2].ServiceName() != "CheckoutService" {
+			t.Errorf("期望 CheckoutService 排在最后，实际: %v", sorted)
+		}
+		if services.Count() != 3 {
+			t.Errorf("期望 Count 包含具名服务，实际 %d", services.Count())
+		}
+	})
+
+	t.Run("多个具名实现_未指定名称返回AmbiguousMatchError", func(t *testing.T) {
+		type consumer struct {
+			Gateway testPaymentGateway `inject:""`
+		}
+		services := newTestGatewayServices(t)
+		err := injectDependencies(&consumer{}, NewGenericDependencyResolver(services))
+		ambiguous, ok := err.(*AmbiguousMatchError)
+		if !ok || ambiguous.FieldName != "Gateway" || len(ambiguous.Candidates) != 2 {
+			t.Errorf("期望 AmbiguousMatchError，实际: %v", err)
+		}
+	})
+
+	t.Run("名称不存在_返回DependencyNotFoundError", func(t *testing.T) {
+		type consumer struct {
+			Gateway testPaymentGateway `inject:"name=alipay"`
+		}
+		services := newTestGatewayServices(t)
+		err := injectDependencies(&consumer{}, NewGenericDependencyResolver(services))
+		notFound, ok := err.(*DependencyNotFoundError)
+		if !ok || notFound.Message != `no implementation named "alipay"` {
+			t.Errorf("期望 DependencyNotFoundError，实际: %v", err)
+		}
+	})
+
+	t.Run("唯一具名实现_未指定名称直接注入", func(t *testing.T) {
+		type consumer struct {
+			Gateway testPaymentGateway `inject:""`
+		}
+		services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+		services.SetManagerContainer(NewManagerContainer())
+		_ = RegisterServiceNamed[testPaymentGateway](services, "stripe", &testGateway{name: "stripe"})
+
+		c := &consumer{}
+		if err := injectDependencies(c, NewGenericDependencyResolver(services)); err != nil || c.Gateway == nil {
+			t.Errorf("期望注入唯一的具名实现，实际: %v", err)
+		}
+	})
+
+	t.Run("重复名称_返回错误", func(t *testing.T) {
+		services := newTestGatewayServices(t)
+		err := RegisterServiceNamed[testPaymentGateway](services, "stripe", &testGateway{name: "stripe"})
+		if _, ok := err.(*DuplicateRegistrationError); !ok {
+			t.Errorf("期望 DuplicateRegistrationError，实际: %v", err)
+		}
+	})
+
+	t.Run("非法标签_返回InvalidInjectTagError", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			instance interface{}
+		}{
+			{"未知选项", &struct {
+				Gateway testPaymentGateway `inject:"qualifier=x"`
+			}{}},
+			{"空名称", &struct {
+				Gateway testPaymentGateway `inject:"name="`
+			}{}},
+			{"切片字段使用名称", &struct {
+				All []testPaymentGateway `inject:"name=stripe"`
+			}{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				services := newTestGatewayServices(t)
+				err := injectDependencies(tt.instance, NewGenericDependencyResolver(services))
+				if _, ok := err.(*InvalidInjectTagError); !ok {
+					t.Errorf("期望 InvalidInjectTagError，实际: %v", err)
+				}
+			})
+		}
+	})
+}
+
+type testGreeterService interface {
+	common.IBaseService
+	Greet() string
+}
+
+// testGreeterServiceImpl 通过构造函数注入依赖的测试服务
+type testGreeterServiceImpl struct {
+	gateway testPaymentGateway
+	all     []testPaymentGateway
+}
+
+func (s *testGreeterServiceImpl) ServiceName() string { return "GreeterService" }
+func (s *testGreeterServiceImpl) OnStart() error      { return nil }
+func (s *testGreeterServiceImpl) OnStop() error       { return nil }
+func (s *testGreeterServiceImpl) Greet() string       { return "hello via " + s.gateway.Pay() }
+
+func newTestGreeterService(gateway testPaymentGateway) testGreeterService {
+	return &testGreeterServiceImpl{gateway: gateway}
+}
+
+// testGreeterController 通过构造函数依赖服务的测试控制器
+type testGreeterController struct {
+	greeter testGreeterService
+}
+
+func (c *testGreeterController) ControllerName() string { return "GreeterController" }
+func (c *testGreeterController) GetRouter() string      { return "/greet [GET]" }
+func (c *testGreeterController) Handle(*gin.Context)    {}
+
+type testGreeterControllerIface interface {
+	common.IBaseController
+}
+
+func TestConstructorInjection(t *testing.T) {
+	newServices := func(t *testing.T) *ServiceContainer {
+		t.Helper()
+		services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+		services.SetManagerContainer(NewManagerContainer())
+		return services
+	}
+
+	t.Run("构造函数依赖构造函数_按拓扑顺序构造", func(t *testing.T) {
+		services := newServices(t)
+		if err := RegisterServiceConstructor[testGreeterService](services, newTestGreeterService); err != nil {
+			t.Fatalf("注册构造函数失败: %v", err)
+		}
+		err := RegisterServiceConstructor[testPaymentGateway](services, func() (testPaymentGateway, error) {
+			return &testGateway{name: "stripe"}, nil
+		})
+		if err != nil {
+			t.Fatalf("注册构造函数失败: %v", err)
+		}
+		if services.Count() != 2 {
+			t.Errorf("期望 Count 包含未调用的构造函数，实际 %d", services.Count())
+		}
+
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("注入失败: %v", err)
+		}
+		greeter, err := GetService[testGreeterService](services)
+		if err != nil || greeter.Greet() != "hello via stripe" {
+			t.Fatalf("构造结果不正确: %v", err)
+		}
+
+		sorted, err := services.GetAllTopological()
+		if err != nil || len(sorted) != 2 || sorted[1].ServiceName() != "GreeterService" {
+			t.Errorf("期望构造函数参数依赖参与启动排序，实际: %v, %v", sorted, err)
+		}
+	})
+
+	t.Run("切片参数_注入所有实现", func(t *testing.T) {
+		services := newTestGatewayServices(t)
+		_ = RegisterServiceConstructor[testGreeterService](services, func(all []testPaymentGateway) testGreeterService {
+			return &testGreeterServiceImpl{gateway: all[0], all: all}
+		})
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("注入失败: %v", err)
+		}
+		greeter, _ := GetService[testGreeterService](services)
+		if len(greeter.(*testGreeterServiceImpl).all) != 2 {
+			t.Errorf("期望注入 2 个网关，实际 %v", greeter)
+		}
+	})
+
+	t.Run("控制器构造函数_依赖服务", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterService[testPaymentGateway](services, &testGateway{name: "stripe"})
+		_ = RegisterServiceConstructor[testGreeterService](services, newTestGreeterService)
+		controllers := NewControllerContainer(services)
+		controllers.SetManagerContainer(services.managerContainer)
+		_ = RegisterControllerConstructor[testGreeterControllerIface](controllers, func(greeter testGreeterService) testGreeterControllerIface {
+			return &testGreeterController{greeter: greeter}
+		})
+
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("服务注入失败: %v", err)
+		}
+		if err := controllers.InjectAll(); err != nil {
+			t.Fatalf("控制器注入失败: %v", err)
+		}
+		ctrl, err := GetController[testGreeterControllerIface](controllers)
+		if err != nil || ctrl.(*testGreeterController).greeter == nil {
+			t.Errorf("控制器构造结果不正确: %v", err)
+		}
+		if types := controllers.ConstructorParamTypes(); len(types) != 1 || types[0] != reflect.TypeOf((*testGreeterService)(nil)).Elem() {
+			t.Errorf("构造函数参数类型不正确: %v", types)
+		}
+	})
+
+	t.Run("参数缺失_返回定位到参数的错误", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterServiceConstructor[testGreeterService](services, newTestGreeterService)
+
+		err := services.InjectAll()
+		var notFound *DependencyNotFoundError
+		if !errors.As(err, &notFound) || notFound.InstanceName != "container.newTestGreeterService" || notFound.FieldName != "arg0" {
+			t.Errorf("期望 DependencyNotFoundError 定位到 arg0，实际: %v", err)
+		}
+	})
+
+	t.Run("构造函数返回错误_返回ConstructorError", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterServiceConstructor[testPaymentGateway](services, func() (testPaymentGateway, error) {
+			return nil, errors.New("boom")
+		})
+		err := services.InjectAll()
+		var ctorErr *ConstructorError
+		if !errors.As(err, &ctorErr) || ctorErr.Err.Error() != "boom" {
+			t.Errorf("期望 ConstructorError，实际: %v", err)
+		}
+	})
+
+	t.Run("签名非法_注册时返回错误", func(t *testing.T) {
+		tests := []struct {
+			name string
+			ctor interface{}
+		}{
+			{"不是函数", "not a func"},
+			{"无返回值", func() {}},
+			{"返回类型不匹配", func() testMissingService { return nil }},
+			{"第二个返回值不是error", func() (testPaymentGateway, int) { return nil, 0 }},
+			{"参数不是接口", func(name string) testPaymentGateway { return nil }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				services := newServices(t)
+				err := RegisterServiceConstructor[testPaymentGateway](services, tt.ctor)
+				if _, ok := err.(*InvalidConstructorError); !ok {
+					t.Errorf("期望 InvalidConstructorError，实际: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("重复注册_返回错误", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterService[testPaymentGateway](services, &testGateway{name: "stripe"})
+		err := RegisterServiceConstructor[testPaymentGateway](services, func() testPaymentGateway { return nil })
+		if _, ok := err.(*InterfaceAlreadyRegisteredError); !ok {
+			t.Errorf("期望 InterfaceAlreadyRegisteredError，实际: %v", err)
+		}
+	})
+
+	t.Run("Replace_替换构造函数", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterServiceConstructor[testGreeterService](services, newTestGreeterService)
+		fake := &testGreeterServiceImpl{gateway: &testGateway{name: "fake"}}
+		if err := ReplaceService[testGreeterService](services, fake); err != nil {
+			t.Fatalf("替换失败: %v", err)
+		}
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("替换后不应再调用构造函数: %v", err)
+		}
+		if greeter, _ := GetService[testGreeterService](services); greeter != fake {
+			t.Error("期望使用替换的实现")
+		}
+	})
+}
+
+type testUserService interface {
+	common.IBaseService
+	Name() string
+}
+
+type testOrderService interface {
+	common.IBaseService
+	Name() string
+}
+
+// testUserServiceImpl 依赖 OrderService 的测试服务
+type testUserServiceImpl struct {
+	Orders testOrderService `inject:""`
+}
+
+func (s *testUserServiceImpl) ServiceName() string { return "UserService" }
+func (s *testUserServiceImpl) OnStart() error      { return nil }
+func (s *testUserServiceImpl) OnStop() error       { return nil }
+func (s *testUserServiceImpl) Name() string        { return "user" }
+
+// testOrderServiceImpl 通过 Lazy 反向依赖 UserService 的测试服务
+type testOrderServiceImpl struct {
+	Users    Lazy[testUserService]    `inject:""`
+	Optional testMissingService       `inject:"optional"`
+	Missing  Lazy[testMissingService] `inject:"optional"`
+}
+
+func (s *testOrderServiceImpl) ServiceName() string { return "OrderService" }
+func (s *testOrderServiceImpl) OnStart() error      { return nil }
+func (s *testOrderServiceImpl) OnStop() error       { return nil }
+func (s *testOrderServiceImpl) Name() string        { return "order" }
+
+func TestOptionalAndLazyInjection(t *testing.T) {
+	newServices := func(t *testing.T) *ServiceContainer {
+		t.Helper()
+		services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+		services.SetManagerContainer(NewManagerContainer())
+		return services
+	}
+
+	t.Run("Lazy打破循环依赖_optional未注册保持nil", func(t *testing.T) {
+		services := newServices(t)
+		users := &testUserServiceImpl{}
+		orders := &testOrderServiceImpl{}
+		_ = RegisterService[testUserService](services, users)
+		_ = RegisterService[testOrderService](services, orders)
+
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("注入失败: %v", err)
+		}
+		if users.Orders != orders || orders.Users.Get() != users {
+			t.Error("Lazy 依赖解析结果不正确")
+		}
+		if orders.Optional != nil {
+			t.Error("optional 依赖未注册时应保持 nil")
+		}
+		if missing, err := orders.Missing.Resolve(); missing != nil || err != nil {
+			t.Errorf("optional Lazy 依赖未注册时应返回 nil, 实际 %v, %v", missing, err)
+		}
+
+		sorted, err := services.GetAllTopological()
+		if err != nil || len(sorted) != 2 || sorted[0].ServiceName() != "OrderService" {
+			t.Errorf("Lazy 依赖不应参与拓扑排序，实际: %v, %v", sorted, err)
+		}
+	})
+
+	t.Run("Lazy依赖未注册_返回错误", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterService[testOrderService](services, &testOrderServiceImpl{})
+
+		err := services.InjectAll()
+		var notFound *DependencyNotFoundError
+		if !errors.As(err, &notFound) || notFound.FieldName != "Users" {
+			t.Errorf("期望 Users 字段的 DependencyNotFoundError，实际: %v", err)
+		}
+	})
+
+	t.Run("构造函数Lazy参数_打破循环依赖", func(t *testing.T) {
+		services := newServices(t)
+		_ = RegisterServiceConstructor[testOrderService](services, func(users Lazy[testUserService]) testOrderService {
+			return &testOrderServiceImpl{Users: users}
+		})
+		_ = RegisterServiceConstructor[testUserService](services, func(orders testOrderService) testUserService {
+			return &testUserServiceImpl{Orders: orders}
+		})
+
+		if err := services.InjectAll(); err != nil {
+			t.Fatalf("注入失败: %v", err)
+		}
+		orders, _ := GetService[testOrderService](services)
+		if orders.(*testOrderServiceImpl).Users.Get().Name() != "user" {
+			t.Error("构造函数 Lazy 参数解析结果不正确")
+		}
+	})
+
+	t.Run("optional管理器被禁用_保持nil", func(t *testing.T) {
+		type consumer struct {
+			Manager testManagerInterface `inject:"optional"`
+		}
+		managers := NewManagerContainer()
+		managers.MarkDisabled(reflect.TypeOf((*testManagerInterface)(nil)).Elem(), "disabled")
+
+		c := &consumer{}
+		if err := injectInstance(c, NewGenericDependencyResolver(managers)); err != nil || c.Manager != nil {
+			t.Errorf("期望 optional 管理器保持 nil，实际: %v", err)
+		}
+	})
+
+	t.Run("optional不忽略分层规则", func(t *testing.T) {
+		type consumer struct {
+			Repo testRepositoryInterface `inject:"optional"`
+		}
+		services := newServices(t)
+		controllers := NewControllerContainer(services)
+		controllers.SetManagerContainer(services.managerContainer)
+
+		err := injectDependencies(&consumer{}, NewGenericDependencyResolver(controllers))
+		var notFound *DependencyNotFoundError
+		if !errors.As(err, &notFound) || notFound.ContainerType != "Repository" {
+			t.Errorf("期望 Controller 注入 Repository 时返回错误，实际: %v", err)
+		}
+	})
+
+	t.Run("未注入的Lazy_Resolve返回错误", func(t *testing.T) {
+		var lazy Lazy[testUserService]
+		if _, err := lazy.Resolve(); err == nil {
+			t.Error("未注入的 Lazy 期望返回错误")
+		}
+	})
+
+	t.Run("NewLazy_仅解析一次", func(t *testing.T) {
+		calls := 0
+		lazy := NewLazy(func() (testUserService, error) {
+			calls++
+			return &testUserServiceImpl{}, nil
+		})
+		copied := lazy
+		lazy.Get()
+		copied.Get()
+		if calls != 1 {
+			t.Errorf("期望解析 1 次，实际 %d", calls)
+		}
+	})
+}
+
+type testRepositoryInterface interface {
+	common.IBaseRepository
+}
+
+func TestDependencyCycles(t *testing.T) {
+	t.Run("仅报告环上的节点_含字段名", func(t *testing.T) {
+		g := newDependencyGraph[string]()
+		g.addEdge("A", "B", "B")
+		g.addEdge("B", "C", "Cache")
+		g.addEdge("C", "A", "Owner")
+		g.addEdge("D", "A", "A") // D 位于环的下游
+		g.addNode("E")
+
+		_, err := g.sort()
+		var cycleErr *CircularDependencyError
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("期望 CircularDependencyError，实际: %v", err)
+		}
+		if len(cycleErr.Cycles) != 1 || len(cycleErr.Cycle) != 3 {
+			t.Fatalf("期望 1 个包含 3 个节点的环，实际: %+v", cycleErr.Cycles)
+		}
+		want := "circular dependency detected: A.B → B.Cache → C.Owner → A"
+		if err.Error() != want {
+			t.Errorf("期望 %q，实际 %q", want, err.Error())
+		}
+	})
+
+	t.Run("多个环_自环", func(t *testing.T) {
+		g := newDependencyGraph[string]()
+		g.addEdge("A", "B", "f1")
+		g.addEdge("B", "A", "f2")
+		g.addEdge("X", "X", "Self")
+		g.addEdge("A", "X", "f3")
+
+		cycles := g.cycles()
+		if len(cycles) != 2 {
+			t.Fatalf("期望 2 个环，实际: %+v", cycles)
+		}
+		if cycles[0].String() != "A.f1 → B.f2 → A" || cycles[1].String() != "X.Self → X" {
+			t.Errorf("环描述不正确: %s; %s", cycles[0], cycles[1])
+		}
+	})
+
+	t.Run("无环_返回空", func(t *testing.T) {
+		g := newDependencyGraph[string]()
+		g.addEdge("A", "B", "f")
+		if cycles := g.cycles(); len(cycles) != 0 {
+			t.Errorf("期望无环，实际: %+v", cycles)
+		}
+	})
+}
+
+type testCycleA interface{ common.IBaseService }
+type testCycleB interface{ common.IBaseService }
+
+// testCycleAImpl 与 testCycleBImpl 互相依赖的测试服务
+type testCycleAImpl struct {
+	Peer testCycleB `inject:""`
+}
+
+func (s *testCycleAImpl) ServiceName() string { return "CycleA" }
+func (s *testCycleAImpl) OnStart() error      { return nil }
+func (s *testCycleAImpl) OnStop() error       { return nil }
+
+type testCycleBImpl struct {
+	Back testCycleA `inject:""`
+}
+
+func (s *testCycleBImpl) ServiceName() string { return "CycleB" }
+func (s *testCycleBImpl) OnStart() error      { return nil }
+func (s *testCycleBImpl) OnStop() error       { return nil }
+
+func TestServiceCycleReport(t *testing.T) {
+	services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+	services.SetManagerContainer(NewManagerContainer())
+	_ = RegisterService[testCycleA](services, &testCycleAImpl{})
+	_ = RegisterService[testCycleB](services, &testCycleBImpl{})
+	_ = RegisterService[testGreeterService](services, &testGreeterServiceImpl{})
+
+	err := services.InjectAll()
+	var cycleErr *CircularDependencyError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("期望 CircularDependencyError，实际: %v", err)
+	}
+	want := "container.testCycleA.Peer → container.testCycleB.Back → container.testCycleA"
+	if len(cycleErr.Cycles) != 1 || cycleErr.Cycles[0].String() != want {
+		t.Errorf("期望环 %q，实际: %v", want, err)
+	}
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	managers := NewManagerContainer()
+	services := NewServiceContainer(NewRepositoryContainer(NewEntityContainer()))
+	services.SetManagerContainer(managers)
+	controllers := NewControllerContainer(services)
+	controllers.SetManagerContainer(managers)
+
+	_ = RegisterServiceNamed[testPaymentGateway](services, "stripe", &testGateway{name: "stripe"})
+	_ = RegisterServiceNamed[testPaymentGateway](services, "paypal", &testGateway{name: "paypal"})
+	_ = RegisterService[testCheckoutService](services, &testCheckoutServiceImpl{})
+	_ = RegisterService[testUserService](services, &testUserServiceImpl{})
+	_ = RegisterService[testOrderService](services, &testOrderServiceImpl{})
+	_ = RegisterServiceConstructor[testGreeterService](services, newTestGreeterService)
+	_ = RegisterControllerConstructor[testGreeterControllerIface](controllers, func(greeter testGreeterService) testGreeterControllerIface {
+		return &testGreeterController{greeter: greeter}
+	})
+
+	graph := BuildDependencyGraph(GraphContainers{Manager: managers, Service: services, Controller: controllers})
+	if len(graph.Nodes) != 7 {
+		t.Fatalf("期望 7 个节点，实际: %+v", graph.Nodes)
+	}
+	if last := graph.Nodes[len(graph.Nodes)-1]; last.Layer != "Controller" || last.Impl != "container.TestBuildDependencyGraph.func1" {
+		t.Errorf("期望 Controller 节点排在最后且为构造函数，实际: %+v", last)
+	}
+
+	edges := make(map[string]DependencyEdge)
+	for _, edge := range graph.Edges {
+		edges[edge.From+"."+edge.Field+"->"+edge.To] = edge
+	}
+	checks := []string{
+		"Service:container.testCheckoutService.Stripe->Service:container.testPaymentGateway(name=stripe)",
+		"Service:container.testCheckoutService.All->Service:container.testPaymentGateway(name=paypal)",
+		"Service:container.testGreeterService.arg0->Service:container.testPaymentGateway",
+		"Controller:container.testGreeterControllerIface.arg0->Service:container.testGreeterService",
+	}
+	for _, key := range checks[:2] {
+		if _, ok := edges[key]; !ok {
+			t.Errorf("缺少依赖边 %s，实际: %+v", key, graph.Edges)
+		}
+	}
+	if _, ok := edges[checks[2]]; ok {
+		t.Error("多个具名实现时未指定名称的依赖不应产生边")
+	}
+	if _, ok := edges[checks[3]]; !ok {
+		t.Errorf("缺少跨层依赖边 %s", checks[3])
+	}
+	if edge := edges["Service:container.testOrderService.Users->Service:container.testUserService"]; !edge.Lazy {
+		t.Errorf("期望 Lazy 依赖边，实际: %+v", edge)
+	}
+
+	if cycles := graph.Cycles(); len(cycles) != 0 {
+		t.Errorf("Lazy 依赖不应构成循环依赖，实际: %+v", cycles)
+	}
+}
+
+// testGraphRepository 依赖图测试用的仓储
+type testGraphRepository struct{}
+
+func (r *testGraphRepository) RepositoryName() string { return "GraphRepository" }
+func (r *testGraphRepository) OnStart() error         { return nil }
+func (r *testGraphRepository) OnStop() error          { return nil }
+
+// testRepoController 直接注入 Repository（违反分层规则）的测试控制器
+type testRepoController struct {
+	Repo testRepositoryInterface `inject:""`
+}
+
+func (c *testRepoController) ControllerName() string { return "RepoController" }
+func (c *testRepoController) GetRouter() string      { return "/repo [GET]" }
+func (c *testRepoController) Handle(*gin.Context)    {}
+
+func TestDependencyGraphExport(t *testing.T) {
+	repositories := NewRepositoryContainer(NewEntityContainer())
+	services := NewServiceContainer(repositories)
+	controllers := NewControllerContainer(services)
+	_ = RegisterRepository[testRepositoryInterface](repositories, &testGraphRepository{})
+	_ = RegisterService[testUserService](services, &testUserServiceImpl{})
+	_ = RegisterService[testOrderService](services, &testOrderServiceImpl{})
+	_ = RegisterController[testGreeterControllerIface](controllers, &testRepoController{})
+
+	graph := BuildDependencyGraph(GraphContainers{Repository: repositories, Service: services, Controller: controllers})
+
+	t.Run("分层违规", func(t *testing.T) {
+		violations := graph.LayerViolations()
+		if len(violations) != 1 {
+			t.Fatalf("期望 1 条违规依赖，实际: %+v", violations)
+		}
+		want := DependencyEdge{
+			From:      "Controller:container.testGreeterControllerIface",
+			To:        "Repository:container.testRepositoryInterface",
+			Field:     "Repo",
+			Violation: true,
+		}
+		if violations[0] != want {
+			t.Errorf("期望 %+v，实际 %+v", want, violations[0])
+		}
+	})
+
+	t.Run("DOT", func(t *testing.T) {
+		out, err := graph.Render(GraphFormatDOT)
+		if err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		wants := []string{
+			"digraph dependencies {",
+			`label="Repository";`,
+			`"Service:container.testUserService" [label="container.testUserService\ntestUserServiceImpl"];`,
+			`"Service:container.testOrderService" -> "Service:container.testUserService" [label="Users", style=dashed];`,
+			`"Controller:container.testGreeterControllerIface" -> "Repository:container.testRepositoryInterface" [label="Repo", color=red, fontcolor=red];`,
+		}
+		for _, want := range wants {
+			if !strings.Contains(out, want) {
+				t.Errorf("DOT 输出缺少 %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("Mermaid", func(t *testing.T) {
+		out, err := graph.Render(GraphFormatMermaid)
+		if err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		wants := []string{
+			"flowchart LR\n",
+			"  subgraph Service\n",
+			`    n0["container.testRepositoryInterface<br/>testGraphRepository"]`,
+			`  n3 -->|"Repo"| n0`,
+			"  linkStyle 0 stroke:red,color:red\n",
+		}
+		for _, want := range wants {
+			if !strings.Contains(out, want) {
+				t.Errorf("Mermaid 输出缺少 %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := graph.Render(GraphFormatJSON)
+		if err != nil {
+			t.Fatalf("导出失败: %v", err)
+		}
+		var decoded DependencyGraph
+		if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+			t.Fatalf("解析 JSON 失败: %v", err)
+		}
+		if !reflect.DeepEqual(&decoded, graph) {
+			t.Errorf("JSON 往返结果不一致:\n%s", out)
+		}
+	})
+
+	t.Run("不支持的格式_返回错误", func(t *testing.T) {
+		if _, err := graph.Render("svg"); err == nil {
+			t.Error("期望返回错误")
+		}
+	})
+}
@@ -28,6 +28,19 @@ func RegisterController[T common.IBaseController](c *ControllerContainer, impl T
 	return c.RegisterByType(ifaceType, impl)
 }
 
+// RegisterControllerNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterControllerNamed[T common.IBaseController](c *ControllerContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return c.RegisterNamedByType(ifaceType, name, impl)
+}
+
+// RegisterControllerConstructor 泛型注册函数，按接口类型注册构造函数
+// 构造函数的参数在依赖注入时解析，如 func(repo IUserRepository) IUserService，可附加返回 error
+func RegisterControllerConstructor[T common.IBaseController](c *ControllerContainer, ctor interface{}) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return c.RegisterConstructorByType(ifaceType, ctor)
+}
+
 // GetController 按接口类型获取
 func GetController[T common.IBaseController](c *ControllerContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -44,7 +57,9 @@ func GetController[T common.IBaseController](c *ControllerContainer) (T, error)
 
 // InjectAll 执行依赖注入
 func (c *ControllerContainer) InjectAll() error {
-	c.checkManagerContainer("Controller")
+	if err := c.checkManagerContainer("Controller"); err != nil {
+		return err
+	}
 
 	if c.InjectableLayerContainer.base.container.IsInjected() {
 		return nil
@@ -56,36 +71,18 @@ func (c *ControllerContainer) InjectAll() error {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (c *ControllerContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
-	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
-	if fieldType == baseRepositoryType || fieldType.Implements(baseRepositoryType) {
-		return nil, &DependencyNotFoundError{
-			FieldType:     fieldType,
-			ContainerType: "Repository",
-			Message:       "Controller cannot directly inject Repository, must access data through Service",
-		}
-	}
+	return c.queryDependency(newTypeQuery(fieldType))
+}
 
-	if dep, err := resolveDependencyFromManager(fieldType, c.managerContainer); dep != nil || err != nil {
-		return dep, err
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (c *ControllerContainer) queryDependency(q dependencyQuery) (interface{}, error) {
+	if err := rejectRepository(q, "Controller"); err != nil {
+		return nil, err
 	}
 
-	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
-	if fieldType == baseServiceType || fieldType.Implements(baseServiceType) {
-		if c.serviceContainer == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		impl := c.serviceContainer.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		return impl, nil
+	if dep, err := resolveDependencyFromManager(q, c.managerContainer); dep != nil || err != nil {
+		return dep, err
 	}
 
-	return nil, nil
+	return resolveDependencyFromService(q, c.serviceContainer)
 }
@@ -0,0 +1,325 @@
+package container
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lite-lake/litecore-go/common"
+)
+
+// DependencyGraph 跨层依赖图
+// 覆盖各层容器中的组件，以及 inject 字段和构造函数参数产生的依赖
+type DependencyGraph struct {
+	Nodes []DependencyNode `json:"nodes"` // 按层（Manager → Scheduler）和 ID 排序
+	Edges []DependencyEdge `json:"edges"` // 按 From、Field 排序
+}
+
+// DependencyNode 依赖图节点
+type DependencyNode struct {
+	ID        string `json:"id"`                  // 唯一标识，如 Service:services.IUserService
+	Layer     string `json:"layer"`               // 所在层：Manager、Entity、Repository、Service、Controller、Middleware、Listener、Scheduler
+	Interface string `json:"interface"`           // 注册的接口类型（Entity 为实体名称）
+	Qualifier string `json:"qualifier,omitempty"` // 具名实现的名称
+	Name      string `json:"name"`                // 组件名称（尚未调用的构造函数为构造函数名）
+	Impl      string `json:"impl"`                // 实现类型（尚未调用的构造函数为构造函数名）
+}
+
+// DependencyEdge 依赖边：From 依赖 To
+type DependencyEdge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Field     string `json:"field"`               // 产生依赖的字段名，构造函数参数为 argN
+	Lazy      bool   `json:"lazy,omitempty"`      // Lazy[T] 依赖，不影响注入顺序
+	Optional  bool   `json:"optional,omitempty"`  // inject:"optional" 依赖
+	Violation bool   `json:"violation,omitempty"` // 违反分层依赖规则（如 Controller 依赖 Repository）
+}
+
+// GraphContainers 构建依赖图的各层容器，为 nil 的层跳过
+type GraphContainers struct {
+	Manager    *ManagerContainer
+	Entity     *EntityContainer
+	Repository *RepositoryContainer
+	Service    *ServiceContainer
+	Controller *ControllerContainer
+	Middleware *MiddlewareContainer
+	Listener   *ListenerContainer
+	Scheduler  *SchedulerContainer
+}
+
+// graphLayerOrder 依赖图中各层的顺序
+var graphLayerOrder = map[string]int{
+	"Manager": 0, "Entity": 1, "Repository": 2, "Service": 3,
+	"Controller": 4, "Middleware": 5, "Listener": 6, "Scheduler": 7,
+}
+
+// graphLayerDeps 各层允许依赖的层，与各层容器解析依赖的范围一致
+var graphLayerDeps = map[string][]string{
+	"Manager":    {"Manager"},
+	"Repository": {"Manager", "Entity"},
+	"Service":    {"Manager", "Repository", "Service"},
+	"Controller": {"Manager", "Service"},
+	"Middleware": {"Manager", "Service"},
+	"Listener":   {"Manager", "Service"},
+	"Scheduler":  {"Manager", "Service"},
+}
+
+// BuildDependencyGraph 构建跨层依赖图
+// 依赖未注册（或无法确定具体实现）的字段不产生边，此类问题由 InjectAll 报告
+func BuildDependencyGraph(c GraphContainers) *DependencyGraph {
+	b := &graphBuilder{containers: c, graph: &DependencyGraph{}}
+
+	if c.Manager != nil {
+		addGraphLayer(b, "Manager", c.Manager.container)
+	}
+	if c.Entity != nil {
+		for _, entity := range c.Entity.GetAll() {
+			b.graph.Nodes = append(b.graph.Nodes, DependencyNode{
+				ID:        entityNodeID(entity),
+				Layer:     "Entity",
+				Interface: entity.EntityName(),
+				Name:      entity.EntityName(),
+				Impl:      extractNameFromType(reflect.TypeOf(entity)),
+			})
+		}
+	}
+	if c.Repository != nil {
+		addGraphLayer(b, "Repository", c.Repository.base.container)
+	}
+	if c.Service != nil {
+		addGraphLayer(b, "Service", c.Service.base.container)
+	}
+	if c.Controller != nil {
+		addGraphLayer(b, "Controller", c.Controller.base.container)
+	}
+	if c.Middleware != nil {
+		addGraphLayer(b, "Middleware", c.Middleware.base.container)
+	}
+	if c.Listener != nil {
+		addGraphLayer(b, "Listener", c.Listener.base.container)
+	}
+	if c.Scheduler != nil {
+		addGraphLayer(b, "Scheduler", c.Scheduler.base.container)
+	}
+
+	nodes := b.graph.Nodes
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Layer != nodes[j].Layer {
+			return graphLayerOrder[nodes[i].Layer] < graphLayerOrder[nodes[j].Layer]
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	edges := b.graph.Edges
+	for i := range edges {
+		edges[i].Violation = !layerAllowed(nodeLayer(edges[i].From), nodeLayer(edges[i].To))
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].Field != edges[j].Field {
+			return edges[i].Field < edges[j].Field
+		}
+		return edges[i].To < edges[j].To
+	})
+	return b.graph
+}
+
+// LayerViolations 返回违反分层依赖规则的边（此类依赖在 InjectAll 时会报错）
+func (g *DependencyGraph) LayerViolations() []DependencyEdge {
+	var violations []DependencyEdge
+	for _, edge := range g.Edges {
+		if edge.Violation {
+			violations = append(violations, edge)
+		}
+	}
+	return violations
+}
+
+// Cycles 返回依赖图中的循环依赖，每个环给出路径和产生各条依赖的字段名
+// Lazy 依赖首次使用时才解析，不构成循环依赖
+func (g *DependencyGraph) Cycles() []DependencyCycle {
+	dg := newDependencyGraph[string]()
+	for _, node := range g.Nodes {
+		dg.addNode(node.ID)
+	}
+	for _, edge := range g.Edges {
+		if !edge.Lazy {
+			dg.addEdge(edge.From, edge.To, edge.Field)
+		}
+	}
+	return dg.cycles()
+}
+
+// graphBuilder 依赖图构建器
+type graphBuilder struct {
+	containers GraphContainers
+	graph      *DependencyGraph
+}
+
+// graphEntry 类型化容器中的一个组件
+type graphEntry struct {
+	node     DependencyNode
+	instance interface{}  // 尚未调用的构造函数为 nil
+	ctor     *constructor // 通过构造函数注册时非 nil
+}
+
+// addGraphLayer 添加一层容器中的组件及其依赖
+func addGraphLayer[T any](b *graphBuilder, layer string, c *TypedContainer[T]) {
+	ctors := c.constructors(false)
+	pending := c.constructors(true)
+
+	// 先收集组件再解析依赖，避免持有容器锁时访问其他容器
+	var entries []graphEntry
+	c.rangeEntries(func(ifaceType reflect.Type, name string, impl T) bool {
+		entry := graphEntry{
+			node: DependencyNode{
+				ID:        typedNodeID(layer, ifaceType, name),
+				Layer:     layer,
+				Interface: ifaceType.String(),
+				Qualifier: name,
+				Name:      c.nameFunc(impl),
+				Impl:      extractNameFromType(reflect.TypeOf(impl)),
+			},
+			instance: impl,
+		}
+		if name == "" {
+			entry.ctor = ctors[ifaceType]
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	for ifaceType, ctor := range pending {
+		entries = append(entries, graphEntry{
+			node: DependencyNode{
+				ID:        typedNodeID(layer, ifaceType, ""),
+				Layer:     layer,
+				Interface: ifaceType.String(),
+				Name:      ctor.name,
+				Impl:      ctor.name,
+			},
+			ctor: ctor,
+		})
+	}
+
+	for _, entry := range entries {
+		b.graph.Nodes = append(b.graph.Nodes, entry.node)
+		if entry.instance != nil {
+			for _, fq := range injectQueries(entry.instance) {
+				b.addEdges(entry.node.ID, fq.field, fq.q)
+			}
+		}
+		if entry.ctor != nil {
+			for i, q := range entry.ctor.params {
+				b.addEdges(entry.node.ID, entry.ctor.paramName(i), q)
+			}
+		}
+	}
+}
+
+// addEdges 添加 from 通过 field 依赖查询命中的组件的边
+func (b *graphBuilder) addEdges(from, field string, q dependencyQuery) {
+	for _, to := range b.targets(q) {
+		b.graph.Edges = append(b.graph.Edges, DependencyEdge{
+			From:     from,
+			To:       to,
+			Field:    field,
+			Lazy:     q.lazy(),
+			Optional: q.optional,
+		})
+	}
+}
+
+// targets 返回查询命中的节点 ID
+func (b *graphBuilder) targets(q dependencyQuery) []string {
+	c := b.containers
+	switch {
+	case q.matches(reflect.TypeOf((*common.IBaseManager)(nil)).Elem()):
+		if c.Manager != nil {
+			return typedTargets("Manager", c.Manager.container, q)
+		}
+	case q.matches(reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()):
+		if c.Repository != nil {
+			return typedTargets("Repository", c.Repository.base.container, q)
+		}
+	case q.matches(reflect.TypeOf((*common.IBaseService)(nil)).Elem()):
+		if c.Service != nil {
+			return typedTargets("Service", c.Service.base.container, q)
+		}
+	case q.matches(reflect.TypeOf((*common.IBaseEntity)(nil)).Elem()):
+		if c.Entity != nil && q.kind == injectSingle && q.name == "" {
+			if dep, err := c.Entity.GetDependency(q.fieldType); err == nil && dep != nil {
+				return []string{entityNodeID(dep.(common.IBaseEntity))}
+			}
+		}
+	}
+	return nil
+}
+
+// typedTargets 返回类型化容器中查询命中的节点 ID
+func typedTargets[T any](layer string, c *TypedContainer[T], q dependencyQuery) []string {
+	names, _ := queryNames(c, q)
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		ids = append(ids, typedNodeID(layer, q.ifaceType, name))
+	}
+	return ids
+}
+
+// fieldQuery 带 inject 标签的字段及其依赖查询
+type fieldQuery struct {
+	field string
+	q     dependencyQuery
+}
+
+// injectQueries 返回实例中带 inject 标签字段的依赖查询（标签非法的字段跳过）
+func injectQueries(instance interface{}) []fieldQuery {
+	typ := reflect.TypeOf(instance)
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var queries []fieldQuery
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if _, ok := field.Tag.Lookup("inject"); !ok {
+			continue
+		}
+		if q, err := parseInjectTag(field); err == nil {
+			queries = append(queries, fieldQuery{field: field.Name, q: q})
+		}
+	}
+	return queries
+}
+
+// typedNodeID 返回类型化容器中组件的节点 ID，如 Service:services.IPaymentGateway(name=stripe)
+func typedNodeID(layer string, ifaceType reflect.Type, name string) string {
+	return layer + ":" + qualifiedName(ifaceType, name)
+}
+
+// entityNodeID 返回实体的节点 ID
+func entityNodeID(entity common.IBaseEntity) string {
+	return "Entity:" + entity.EntityName()
+}
+
+// nodeLayer 返回节点 ID 中的层名称
+func nodeLayer(id string) string {
+	layer, _, _ := strings.Cut(id, ":")
+	return layer
+}
+
+// layerAllowed 判断 from 层是否允许依赖 to 层
+func layerAllowed(from, to string) bool {
+	for _, layer := range graphLayerDeps[from] {
+		if layer == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// 依赖图导出格式
+const (
+	GraphFormatDOT     = "dot"     // Graphviz DOT
+	GraphFormatMermaid = "mermaid" // Mermaid flowchart
+	GraphFormatJSON    = "json"    // DependencyGraph 的 JSON 序列化
+)
+
+// GraphFormats 支持的依赖图导出格式
+var GraphFormats = []string{GraphFormatDOT, GraphFormatMermaid, GraphFormatJSON}
+
+// Render 按格式导出依赖图
+func (g *DependencyGraph) Render(format string) (string, error) {
+	switch format {
+	case GraphFormatDOT:
+		return g.DOT(), nil
+	case GraphFormatMermaid:
+		return g.Mermaid(), nil
+	case GraphFormatJSON:
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+	return "", fmt.Errorf("unsupported graph format %q, expected one of %s", format, strings.Join(GraphFormats, ", "))
+}
+
+// DOT 导出 Graphviz DOT 格式：每层一个子图，Lazy 依赖为虚线，可选依赖为点线，违反分层规则的依赖为红色
+func (g *DependencyGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for i, layer := range g.layers() {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(layer.name))
+		for _, node := range layer.nodes {
+			fmt.Fprintf(&b, "    %s [label=%s];\n", dotQuote(node.ID), dotQuote(node.label()))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, edge := range g.Edges {
+		attrs := []string{"label=" + dotQuote(edge.Field)}
+		switch {
+		case edge.Lazy:
+			attrs = append(attrs, "style=dashed")
+		case edge.Optional:
+			attrs = append(attrs, "style=dotted")
+		}
+		if edge.Violation {
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid 导出 Mermaid flowchart 格式：每层一个子图，Lazy 和可选依赖为虚线，违反分层规则的依赖为红色
+func (g *DependencyGraph) Mermaid() string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, layer := range g.layers() {
+		fmt.Fprintf(&b, "  subgraph %s\n", layer.name)
+		for _, node := range layer.nodes {
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[node.ID], mermaidEscape(node.label()))
+		}
+		b.WriteString("  end\n")
+	}
+	var violations []string
+	for i, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Lazy || edge.Optional {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|\"%s\"| %s\n", ids[edge.From], arrow, mermaidEscape(edge.Field), ids[edge.To])
+		if edge.Violation {
+			violations = append(violations, fmt.Sprint(i))
+		}
+	}
+	if len(violations) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red,color:red\n", strings.Join(violations, ","))
+	}
+	return b.String()
+}
+
+// graphLayer 依赖图中一层的节点
+type graphLayer struct {
+	name  string
+	nodes []DependencyNode
+}
+
+// layers 按节点顺序将节点分组到各层
+func (g *DependencyGraph) layers() []graphLayer {
+	var layers []graphLayer
+	for _, node := range g.Nodes {
+		if len(layers) == 0 || layers[len(layers)-1].name != node.Layer {
+			layers = append(layers, graphLayer{name: node.Layer})
+		}
+		last := &layers[len(layers)-1]
+		last.nodes = append(last.nodes, node)
+	}
+	return layers
+}
+
+// label 返回节点的展示文本：接口（含具名实现名称）和实现类型
+func (n DependencyNode) label() string {
+	title := n.Interface
+	if n.Qualifier != "" {
+		title = fmt.Sprintf("%s(name=%s)", n.Interface, n.Qualifier)
+	}
+	if n.Impl == "" || n.Impl == n.Interface {
+		return title
+	}
+	return title + "\n" + n.Impl
+}
+
+// dotQuote 返回 DOT 双引号字符串
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// mermaidEscape 转义 Mermaid 标签中的特殊字符
+func mermaidEscape(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return strings.ReplaceAll(s, "\n", "<br/>")
+}
@@ -31,13 +31,34 @@
 // 服务层的依赖注入支持拓扑排序，确保依赖按正确顺序注入。
 // 例如：ServiceA 依赖 ServiceB，ServiceB 依赖 ServiceC，
 // 注入顺序为：ServiceC → ServiceB → ServiceA。循环依赖会触发 CircularDependencyError。
+// CircularDependencyError 报告实际的环路径和产生依赖的字段名，如 IOrderService.Users → IUserService.Orders → IOrderService。
+//
+// 跨层依赖图：
+//
+// BuildDependencyGraph 构建 Manager 到 Scheduler 各层组件的依赖图，DependencyGraph.Cycles 对整张图做同样的环分析。
+// DependencyGraph.Render 将依赖图导出为 DOT、Mermaid 或 JSON，LayerViolations 返回违反分层依赖规则的边。
+//
+// 具名注入：
+//
+// 同一接口可通过 RegisterServiceNamed 等函数注册多个具名实现，
+// 使用 inject:"name=stripe" 注入指定实现，或使用 []I、map[string]I 字段注入所有实现。
+//
+// 构造函数注入：
+//
+// 通过 RegisterServiceConstructor 等函数注册构造函数（如 func(repo IUserRepository) IUserService），
+// 参数在 InjectAll 时使用相同的解析器解析，Service 层的构造函数按参数依赖的拓扑顺序调用。
+//
+// 可选依赖与延迟依赖：
+//
+// inject:"optional" 的字段在依赖未注册时保持零值；container.Lazy[T] 字段在首次调用 Get 时解析，
+// Service 之间的 Lazy 依赖不参与拓扑排序，可用于打破合理的循环依赖。
 //
 // 错误处理：
 //
 // 包中定义了多种错误类型：
 //   - DependencyNotFoundError：依赖未找到
 //   - CircularDependencyError：Service 层循环依赖
-//   - AmbiguousMatchError：多重匹配（Entity 层同类型多个实例）
+//   - AmbiguousMatchError：多重匹配（Entity 层同类型多个实例，或多个具名实现未指定名称）
 //   - DuplicateRegistrationError：重复注册
 //   - InstanceNotFoundError：实例未找到
 //   - InterfaceAlreadyRegisteredError：接口已注册
@@ -45,4 +66,9 @@
 //   - InterfaceNotRegisteredError：接口未注册
 //   - ManagerContainerNotSetError：ManagerContainer 未设置
 //   - UninjectedFieldError：标记 inject:"" 的字段注入后仍为 nil
+//   - InvalidInjectTagError：inject 标签非法
+//   - InvalidConstructorError：构造函数签名非法
+//   - ConstructorError：构造函数返回错误或 nil 实例
+//
+// InjectAll 在字段或实例注入失败时继续处理其余部分，多个错误通过 errors.Join 合并返回。
 package container
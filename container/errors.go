@@ -13,6 +13,7 @@ type DependencyNotFoundError struct {
 	FieldType     reflect.Type // 期望的依赖类型
 	ContainerType string       // 应该从哪个容器查找
 	Message       string       // 额外的错误信息
+	forbidden     bool         // 违反分层或注入规则（而不是未注册），optional 依赖不忽略此类错误
 }
 
 // Error 返回错误信息
@@ -27,11 +28,19 @@ func (e *DependencyNotFoundError) Error() string {
 
 // CircularDependencyError 循环依赖错误
 type CircularDependencyError struct {
-	Cycle []string // 循环依赖链
+	Cycle  []string          // 循环依赖链（第一条环上的节点）
+	Cycles []DependencyCycle // 每个强连通分量中的一条环，含产生各条依赖的字段名
 }
 
-// Error 返回错误信息
+// Error 返回错误信息，如 circular dependency detected: A.Orders → B.Users → A
 func (e *CircularDependencyError) Error() string {
+	if len(e.Cycles) > 0 {
+		parts := make([]string, 0, len(e.Cycles))
+		for _, cycle := range e.Cycles {
+			parts = append(parts, cycle.String())
+		}
+		return "circular dependency detected: " + strings.Join(parts, "; ")
+	}
 	if len(e.Cycle) == 0 {
 		return "circular dependency detected"
 	}
@@ -39,6 +48,30 @@ func (e *CircularDependencyError) Error() string {
 		strings.Join(e.Cycle, " → "), e.Cycle[0])
 }
 
+// DependencyCycle 一条循环依赖路径
+type DependencyCycle struct {
+	Path   []string `json:"path"`   // 环上的节点，不重复首节点
+	Fields []string `json:"fields"` // Fields[i] 为 Path[i] 上产生指向下一节点依赖的字段名，未知时为空
+}
+
+// String 返回环的描述，如 A.Orders → B.Users → A
+func (c DependencyCycle) String() string {
+	if len(c.Path) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, node := range c.Path {
+		b.WriteString(node)
+		if i < len(c.Fields) && c.Fields[i] != "" {
+			b.WriteString(".")
+			b.WriteString(c.Fields[i])
+		}
+		b.WriteString(" → ")
+	}
+	b.WriteString(c.Path[0])
+	return b.String()
+}
+
 // AmbiguousMatchError 多重匹配错误
 type AmbiguousMatchError struct {
 	InstanceName string
@@ -109,6 +142,16 @@ func (e *InterfaceNotRegisteredError) Error() string {
 	return fmt.Sprintf("interface %v not registered", e.InterfaceType)
 }
 
+// ReplaceAfterInjectionError 依赖注入完成后替换实例错误
+type ReplaceAfterInjectionError struct {
+	InterfaceType reflect.Type
+}
+
+// Error 返回错误信息
+func (e *ReplaceAfterInjectionError) Error() string {
+	return fmt.Sprintf("cannot replace interface %v after injection", e.InterfaceType)
+}
+
 // ManagerContainerNotSetError ManagerContainer 未设置错误
 type ManagerContainerNotSetError struct {
 	Layer string
@@ -118,3 +161,48 @@ type ManagerContainerNotSetError struct {
 func (e *ManagerContainerNotSetError) Error() string {
 	return "manager container not set before injection"
 }
+
+// InvalidInjectTagError inject 标签非法错误
+type InvalidInjectTagError struct {
+	InstanceName string
+	FieldName    string
+	Tag          string
+	Err          error
+}
+
+// Error 返回错误信息
+func (e *InvalidInjectTagError) Error() string {
+	return fmt.Sprintf("invalid inject tag on %s.%s: %v", e.InstanceName, e.FieldName, e.Err)
+}
+
+// Unwrap 返回底层错误
+func (e *InvalidInjectTagError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidConstructorError 构造函数签名非法错误
+type InvalidConstructorError struct {
+	Constructor string
+	Reason      string
+}
+
+// Error 返回错误信息
+func (e *InvalidConstructorError) Error() string {
+	return fmt.Sprintf("invalid constructor %s: %s", e.Constructor, e.Reason)
+}
+
+// ConstructorError 构造函数返回错误或 nil 实例
+type ConstructorError struct {
+	Constructor string
+	Err         error
+}
+
+// Error 返回错误信息
+func (e *ConstructorError) Error() string {
+	return fmt.Sprintf("constructor %s failed: %v", e.Constructor, e.Err)
+}
+
+// Unwrap 返回底层错误
+func (e *ConstructorError) Unwrap() error {
+	return e.Err
+}
@@ -57,9 +57,25 @@ func (c *InjectableLayerContainer[T]) RegisterByType(ifaceType reflect.Type, imp
 	return c.base.container.Register(ifaceType, impl)
 }
 
+// RegisterNamedByType 按类型和名称注册具名实例
+func (c *InjectableLayerContainer[T]) RegisterNamedByType(ifaceType reflect.Type, name string, impl T) error {
+	return c.base.container.RegisterNamed(ifaceType, name, impl)
+}
+
+// RegisterConstructorByType 按类型注册构造函数
+func (c *InjectableLayerContainer[T]) RegisterConstructorByType(ifaceType reflect.Type, ctor interface{}) error {
+	return c.base.container.RegisterConstructor(ifaceType, ctor)
+}
+
+// ConstructorParamTypes 返回所有构造函数参数依赖的接口类型（切片、map 参数返回元素类型）
+func (c *InjectableLayerContainer[T]) ConstructorParamTypes() []reflect.Type {
+	return c.base.container.constructorParamTypes()
+}
+
 // checkManagerContainer 检查 ManagerContainer 是否已设置
-func (c *InjectableLayerContainer[T]) checkManagerContainer(layerName string) {
+func (c *InjectableLayerContainer[T]) checkManagerContainer(layerName string) error {
 	if c.managerContainer == nil {
-		panic(&ManagerContainerNotSetError{Layer: layerName})
+		return &ManagerContainerNotSetError{Layer: layerName}
 	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -22,20 +23,21 @@ func (e *UninjectedFieldError) Error() string {
 		e.InstanceName, e.FieldName, e.FieldType)
 }
 
-// verifyInjectTags 验证所有 inject:"" 标签的字段是否已被注入
-func verifyInjectTags(instance interface{}) {
+// verifyInjectTags 验证所有 inject 标签的字段（optional 除外）是否已被注入，返回所有未注入字段的错误
+func verifyInjectTags(instance interface{}) error {
 	val := reflect.ValueOf(instance)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
 
 	if val.Kind() != reflect.Struct {
-		return
+		return nil
 	}
 
 	typ := val.Type()
 	instanceName := extractNameFromType(typ)
 
+	var errs []error
 	for i := 0; i < val.NumField(); i++ {
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
@@ -45,14 +47,42 @@ func verifyInjectTags(instance interface{}) {
 			continue
 		}
 
-		if !fieldVal.CanInterface() || fieldVal.IsZero() || fieldVal.IsNil() {
-			panic(&UninjectedFieldError{
+		if q, err := parseInjectTag(field); err == nil && q.optional {
+			continue
+		}
+
+		if !fieldVal.CanInterface() || fieldVal.IsZero() {
+			errs = append(errs, &UninjectedFieldError{
 				InstanceName: instanceName,
 				FieldName:    field.Name,
 				FieldType:    field.Type,
 			})
 		}
 	}
+	return joinErrors(errs)
+}
+
+// InjectFieldTypes 返回实例中带 inject 标签的字段所依赖的接口类型（切片、map 字段返回元素类型）
+func InjectFieldTypes(instance interface{}) []reflect.Type {
+	typ := reflect.TypeOf(instance)
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var types []reflect.Type
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if _, ok := field.Tag.Lookup("inject"); ok {
+			types = append(types, newTypeQuery(field.Type).ifaceType)
+		}
+	}
+	return types
 }
 
 // IDependencyResolver 依赖解析器接口
@@ -67,8 +97,14 @@ type ContainerSource interface {
 	GetDependency(fieldType reflect.Type) (interface{}, error)
 }
 
+// querySource 支持限定名称、切片和 map 查询的容器依赖源（各层容器均实现）
+type querySource interface {
+	queryDependency(q dependencyQuery) (interface{}, error)
+}
+
 // injectDependencies 向实例注入依赖
-// 使用反射解析实例字段，根据 inject 标签查找并注入依赖
+// 使用反射解析实例字段，根据 inject 标签查找并注入依赖；
+// 某个字段解析失败时继续处理其余字段，返回所有字段的错误
 func injectDependencies(instance interface{}, resolver IDependencyResolver) error {
 	val := reflect.ValueOf(instance)
 	if val.Kind() == reflect.Ptr {
@@ -81,6 +117,7 @@ func injectDependencies(instance interface{}, resolver IDependencyResolver) erro
 
 	typ := val.Type()
 
+	var errs []error
 	for i := 0; i < val.NumField(); i++ {
 		field := typ.Field(i)
 		fieldVal := val.Field(i)
@@ -90,20 +127,94 @@ func injectDependencies(instance interface{}, resolver IDependencyResolver) erro
 			continue
 		}
 
-		dependency, err := resolver.ResolveDependency(field.Type, typ, field.Name)
+		q, err := parseInjectTag(field)
 		if err != nil {
-			return err
+			errs = append(errs, &InvalidInjectTagError{
+				InstanceName: extractNameFromType(typ),
+				FieldName:    field.Name,
+				Tag:          field.Tag.Get("inject"),
+				Err:          err,
+			})
+			continue
 		}
 
-		if fieldVal.CanSet() {
-			fieldVal.Set(reflect.ValueOf(dependency))
-		} else {
-			fieldPtr := unsafe.Pointer(fieldVal.UnsafeAddr())
-			reflect.NewAt(field.Type, fieldPtr).Elem().Set(reflect.ValueOf(dependency))
+		dependency, err := resolveInjection(resolver, q, typ, extractNameFromType(typ), field.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if dependency.IsValid() {
+			settableField(fieldVal, field).Set(dependency)
 		}
 	}
 
-	return nil
+	return joinErrors(errs)
+}
+
+// resolveInjection 解析字段（或构造函数参数）的依赖
+// Lazy 依赖返回延迟解析的包装；optional 依赖未注册时返回无效的 reflect.Value，保持零值
+func resolveInjection(resolver IDependencyResolver, q dependencyQuery, structType reflect.Type, instanceName, fieldName string) (reflect.Value, error) {
+	resolve := func() (interface{}, error) {
+		dep, err := resolveField(resolver, q, structType, fieldName)
+		if err != nil {
+			if q.optional && isMissingDependency(err) {
+				return nil, nil
+			}
+			annotateDependencyError(err, instanceName, fieldName)
+			return nil, err
+		}
+		return dep, nil
+	}
+
+	if q.lazy() {
+		return newLazyValue(q.lazyType, resolve), nil
+	}
+	dep, err := resolve()
+	if err != nil || dep == nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(dep), nil
+}
+
+// isMissingDependency 判断错误是否为依赖未注册（或管理器被禁用），违反分层规则的错误不算
+func isMissingDependency(err error) bool {
+	var notFound *DependencyNotFoundError
+	return errors.As(err, &notFound) && !notFound.forbidden
+}
+
+// annotateDependencyError 为依赖错误补充实例名和字段名
+func annotateDependencyError(err error, instanceName, fieldName string) {
+	var notFound *DependencyNotFoundError
+	if errors.As(err, &notFound) && notFound.InstanceName == "" {
+		notFound.InstanceName = instanceName
+		notFound.FieldName = fieldName
+	}
+	var ambiguous *AmbiguousMatchError
+	if errors.As(err, &ambiguous) && ambiguous.InstanceName == "" {
+		ambiguous.InstanceName = instanceName
+		ambiguous.FieldName = fieldName
+	}
+}
+
+// settableField 返回可读写的字段值，未导出字段通过 unsafe.Pointer 访问
+func settableField(fieldVal reflect.Value, field reflect.StructField) reflect.Value {
+	if fieldVal.CanSet() {
+		return fieldVal
+	}
+	fieldPtr := unsafe.Pointer(fieldVal.UnsafeAddr())
+	return reflect.NewAt(field.Type, fieldPtr).Elem()
+}
+
+// joinErrors 合并多个错误：无错误返回 nil，单个错误原样返回，多个错误使用 errors.Join
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
 }
 
 // extractNameFromType 从类型名称中提取简单名称
@@ -148,9 +259,22 @@ func NewGenericDependencyResolver(
 // ResolveDependency 解析字段类型对应的依赖实例
 // 按照sources的顺序依次尝试解析，找到第一个匹配的依赖
 func (r *GenericDependencyResolver) ResolveDependency(fieldType reflect.Type, structType reflect.Type, fieldName string) (interface{}, error) {
+	return r.resolveQuery(newTypeQuery(fieldType))
+}
 
+// resolveQuery 按查询解析依赖，支持限定名称、切片和 map 注入
+// 仅实现 ContainerSource 的依赖源只参与默认实现的解析
+func (r *GenericDependencyResolver) resolveQuery(q dependencyQuery) (interface{}, error) {
 	for _, source := range r.sources {
-		dep, err := source.GetDependency(fieldType)
+		var dep interface{}
+		var err error
+		if qs, ok := source.(querySource); ok {
+			dep, err = qs.queryDependency(q)
+		} else if q.kind == injectSingle && q.name == "" {
+			dep, err = source.GetDependency(q.fieldType)
+		} else {
+			continue
+		}
 		if dep != nil {
 			return dep, nil
 		}
@@ -159,10 +283,23 @@ func (r *GenericDependencyResolver) ResolveDependency(fieldType reflect.Type, st
 		}
 	}
 
-	return nil, &DependencyNotFoundError{
-		FieldType:     fieldType,
-		ContainerType: "Unknown",
+	return nil, q.notFound("Unknown")
+}
+
+// queryResolver 支持按查询解析依赖的解析器
+type queryResolver interface {
+	resolveQuery(q dependencyQuery) (interface{}, error)
+}
+
+// resolveField 使用解析器解析字段的依赖查询，不支持查询的自定义解析器仅解析默认实现
+func resolveField(resolver IDependencyResolver, q dependencyQuery, structType reflect.Type, fieldName string) (interface{}, error) {
+	if qr, ok := resolver.(queryResolver); ok {
+		return qr.resolveQuery(q)
+	}
+	if q.kind != injectSingle || q.name != "" {
+		return nil, fmt.Errorf("resolver %T does not support qualified or collection injection", resolver)
 	}
+	return resolver.ResolveDependency(q.fieldType, structType, fieldName)
 }
 
 // extractLoggerName 从结构体类型推断 logger 名称
@@ -173,7 +310,7 @@ func (r *GenericDependencyResolver) extractLoggerName(structType reflect.Type) s
 
 // resolveDependencyFromManager 从管理器容器解析依赖
 func resolveDependencyFromManager(
-	fieldType reflect.Type,
+	q dependencyQuery,
 	managerContainer *ManagerContainer,
 ) (interface{}, error) {
 	if managerContainer == nil {
@@ -181,22 +318,60 @@ func resolveDependencyFromManager(
 	}
 
 	baseManagerType := reflect.TypeOf((*common.IBaseManager)(nil)).Elem()
-	if fieldType == baseManagerType || fieldType.Implements(baseManagerType) {
-		impl := managerContainer.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Manager",
-			}
-		}
-		return impl, nil
+	if q.matches(baseManagerType) {
+		return managerContainer.queryDependency(q)
 	}
 	return nil, nil
 }
 
+// resolveDependencyFromService 从服务容器解析依赖
+func resolveDependencyFromService(
+	q dependencyQuery,
+	serviceContainer *ServiceContainer,
+) (interface{}, error) {
+	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
+	if !q.matches(baseServiceType) {
+		return nil, nil
+	}
+	if serviceContainer == nil {
+		return nil, q.notFound("Service")
+	}
+	return lookupDependency(serviceContainer.base.container, q, "Service")
+}
+
+// resolveDependencyFromRepository 从仓储容器解析依赖
+func resolveDependencyFromRepository(
+	q dependencyQuery,
+	repositoryContainer *RepositoryContainer,
+) (interface{}, error) {
+	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
+	if !q.matches(baseRepositoryType) {
+		return nil, nil
+	}
+	if repositoryContainer == nil {
+		return nil, q.notFound("Repository")
+	}
+	return lookupDependency(repositoryContainer.base.container, q, "Repository")
+}
+
+// rejectRepository 上层组件（Controller、Middleware、Listener、Scheduler）不能直接注入 Repository
+func rejectRepository(q dependencyQuery, layer string) error {
+	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
+	if !q.matches(baseRepositoryType) {
+		return nil
+	}
+	return &DependencyNotFoundError{
+		FieldType:     q.ifaceType,
+		ContainerType: "Repository",
+		Message:       layer + " cannot directly inject Repository, must access data through Service",
+		forbidden:     true,
+	}
+}
+
 // resolveDependencyFromEntity 从实体容器解析依赖
+// 实体按名称注册，不支持限定名称、切片和 map 注入
 func resolveDependencyFromEntity(
-	fieldType reflect.Type,
+	q dependencyQuery,
 	entityContainer *EntityContainer,
 ) (interface{}, error) {
 	if entityContainer == nil {
@@ -204,28 +379,16 @@ func resolveDependencyFromEntity(
 	}
 
 	baseEntityType := reflect.TypeOf((*common.IBaseEntity)(nil)).Elem()
-	if fieldType == baseEntityType || fieldType.Implements(baseEntityType) {
-		items, err := entityContainer.GetByType(fieldType)
-		if err != nil {
-			return nil, err
-		}
-		if len(items) == 0 {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Entity",
-			}
-		}
-		if len(items) > 1 {
-			var names []string
-			for _, item := range items {
-				names = append(names, item.EntityName())
-			}
-			return nil, &AmbiguousMatchError{
-				FieldType:  fieldType,
-				Candidates: names,
-			}
+	if !q.matches(baseEntityType) {
+		return nil, nil
+	}
+	if q.kind != injectSingle || q.name != "" {
+		return nil, &DependencyNotFoundError{
+			FieldType:     q.fieldType,
+			ContainerType: "Entity",
+			Message:       "Entity does not support qualified or collection injection",
+			forbidden:     true,
 		}
-		return items[0], nil
 	}
-	return nil, nil
+	return entityContainer.GetDependency(q.fieldType)
 }
@@ -0,0 +1,137 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy 延迟依赖，依赖在首次调用 Get 时才解析
+// 用作 inject 字段或构造函数参数，Service 之间的 Lazy 依赖不参与拓扑排序，可用于打破合理的循环依赖：
+//
+//	type OrderServiceImpl struct {
+//		UserService container.Lazy[IUserService] `inject:""`
+//	}
+//
+//	user := s.UserService.Get().FindByID(id)
+//
+// Lazy 可以按值复制，复制后共享同一解析结果
+type Lazy[T any] struct {
+	state *lazyState[T]
+}
+
+// lazyState 延迟依赖的解析状态
+type lazyState[T any] struct {
+	once    sync.Once
+	resolve func() (T, error)
+	value   T
+	err     error
+}
+
+// NewLazy 使用解析函数创建延迟依赖（用于单元测试或手动装配）
+func NewLazy[T any](resolve func() (T, error)) Lazy[T] {
+	return Lazy[T]{state: &lazyState[T]{resolve: resolve}}
+}
+
+// Get 返回依赖实例，解析失败时 panic
+// 非 optional 的依赖在注入时已检查注册情况，通常不会失败；需要处理错误时使用 Resolve
+func (l Lazy[T]) Get() T {
+	value, err := l.Resolve()
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Resolve 返回依赖实例和解析错误，仅在首次调用时解析
+// optional 依赖未注册时返回零值和 nil
+func (l Lazy[T]) Resolve() (T, error) {
+	if l.state == nil {
+		var zero T
+		return zero, fmt.Errorf("container.Lazy[%s] is not injected", reflect.TypeOf((*T)(nil)).Elem())
+	}
+	l.state.once.Do(func() {
+		l.state.value, l.state.err = l.state.resolve()
+	})
+	return l.state.value, l.state.err
+}
+
+// lazyCheck 检查依赖能否解析，不缓存结果（首次使用时才真正解析）
+func (l Lazy[T]) lazyCheck() error {
+	if l.state == nil {
+		_, err := l.Resolve()
+		return err
+	}
+	_, err := l.state.resolve()
+	return err
+}
+
+// lazyTarget 返回延迟解析的依赖类型
+func (Lazy[T]) lazyTarget() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// bindLazy 使用解析函数创建同类型的延迟依赖
+func (Lazy[T]) bindLazy(resolve func() (interface{}, error)) interface{} {
+	return NewLazy(func() (T, error) {
+		var zero T
+		dep, err := resolve()
+		if err != nil || dep == nil {
+			return zero, err
+		}
+		return dep.(T), nil
+	})
+}
+
+// lazyDependency 由 Lazy[T] 实现，注入器据此识别延迟依赖
+type lazyDependency interface {
+	lazyCheck() error
+	lazyTarget() reflect.Type
+	bindLazy(resolve func() (interface{}, error)) interface{}
+}
+
+var lazyDependencyType = reflect.TypeOf((*lazyDependency)(nil)).Elem()
+
+// newLazyValue 创建 lazyType 类型的延迟依赖，首次使用时通过 resolve 解析
+func newLazyValue(lazyType reflect.Type, resolve func() (interface{}, error)) reflect.Value {
+	zero := reflect.Zero(lazyType).Interface().(lazyDependency)
+	return reflect.ValueOf(zero.bindLazy(resolve))
+}
+
+// lazyFields 返回实例中非 optional 的 Lazy 注入字段
+func lazyFields(instance interface{}) []lazyDependency {
+	val := reflect.ValueOf(instance)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	typ := val.Type()
+	var lazies []lazyDependency
+	for i := 0; i < val.NumField(); i++ {
+		field := typ.Field(i)
+		if _, ok := field.Tag.Lookup("inject"); !ok {
+			continue
+		}
+		q, err := parseInjectTag(field)
+		if err != nil || !q.lazy() || q.optional {
+			continue
+		}
+		lazies = append(lazies, settableField(val.Field(i), field).Interface().(lazyDependency))
+	}
+	return lazies
+}
+
+// checkLazyDependencies 检查延迟依赖能否解析，返回所有错误
+// 在所在层的全部实例注入完成后调用，此时 Lazy 依赖的目标已就绪
+func checkLazyDependencies(lazies []lazyDependency) error {
+	var errs []error
+	for _, lazy := range lazies {
+		if err := lazy.lazyCheck(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs)
+}
@@ -33,6 +33,19 @@ func RegisterListener[T common.IBaseListener](l *ListenerContainer, impl T) erro
 	return l.RegisterByType(ifaceType, impl)
 }
 
+// RegisterListenerNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterListenerNamed[T common.IBaseListener](l *ListenerContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return l.RegisterNamedByType(ifaceType, name, impl)
+}
+
+// RegisterListenerConstructor 泛型注册函数，按接口类型注册构造函数
+// 构造函数的参数在依赖注入时解析，如 func(repo IUserRepository) IUserService，可附加返回 error
+func RegisterListenerConstructor[T common.IBaseListener](l *ListenerContainer, ctor interface{}) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return l.RegisterConstructorByType(ifaceType, ctor)
+}
+
 // GetListener 按接口类型获取
 func GetListener[T common.IBaseListener](l *ListenerContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -49,7 +62,9 @@ func GetListener[T common.IBaseListener](l *ListenerContainer) (T, error) {
 
 // InjectAll 执行依赖注入
 func (l *ListenerContainer) InjectAll() error {
-	l.checkManagerContainer("Listener")
+	if err := l.checkManagerContainer("Listener"); err != nil {
+		return err
+	}
 
 	if l.InjectableLayerContainer.base.container.IsInjected() {
 		return nil
@@ -61,36 +76,18 @@ func (l *ListenerContainer) InjectAll() error {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (l *ListenerContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
-	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
-	if fieldType == baseRepositoryType || fieldType.Implements(baseRepositoryType) {
-		return nil, &DependencyNotFoundError{
-			FieldType:     fieldType,
-			ContainerType: "Repository",
-			Message:       "Listener cannot directly inject Repository, must access data through Service",
-		}
-	}
+	return l.queryDependency(newTypeQuery(fieldType))
+}
 
-	if dep, err := resolveDependencyFromManager(fieldType, l.managerContainer); dep != nil || err != nil {
-		return dep, err
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (l *ListenerContainer) queryDependency(q dependencyQuery) (interface{}, error) {
+	if err := rejectRepository(q, "Listener"); err != nil {
+		return nil, err
 	}
 
-	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
-	if fieldType == baseServiceType || fieldType.Implements(baseServiceType) {
-		if l.serviceContainer == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		impl := l.serviceContainer.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		return impl, nil
+	if dep, err := resolveDependencyFromManager(q, l.managerContainer); dep != nil || err != nil {
+		return dep, err
 	}
 
-	return nil, nil
+	return resolveDependencyFromService(q, l.serviceContainer)
 }
@@ -1,6 +1,7 @@
 package container
 
 import (
+	"errors"
 	"reflect"
 	"sort"
 
@@ -10,6 +11,7 @@ import (
 // ManagerContainer 管理器层容器
 type ManagerContainer struct {
 	container *TypedContainer[common.IBaseManager]
+	disabled  map[reflect.Type]string // 被配置禁用的管理器接口及原因
 }
 
 // NewManagerContainer 创建新的管理器容器
@@ -18,6 +20,7 @@ func NewManagerContainer() *ManagerContainer {
 		container: NewTypedContainer(func(manager common.IBaseManager) string {
 			return manager.ManagerName()
 		}),
+		disabled: make(map[reflect.Type]string),
 	}
 }
 
@@ -27,6 +30,12 @@ func RegisterManager[T common.IBaseManager](m *ManagerContainer, impl T) error {
 	return m.RegisterByType(ifaceType, impl)
 }
 
+// RegisterManagerNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterManagerNamed[T common.IBaseManager](m *ManagerContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return m.RegisterNamedByType(ifaceType, name, impl)
+}
+
 // GetManager 按接口类型获取
 func GetManager[T common.IBaseManager](m *ManagerContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -41,16 +50,55 @@ func GetManager[T common.IBaseManager](m *ManagerContainer) (T, error) {
 	return impl.(T), nil
 }
 
+// ReplaceManager 泛型替换函数，按接口类型替换已注册的实现（需在依赖注入之前调用）
+func ReplaceManager[T common.IBaseManager](m *ManagerContainer, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return m.ReplaceByType(ifaceType, impl)
+}
+
 // RegisterByType 按接口类型注册
 func (m *ManagerContainer) RegisterByType(ifaceType reflect.Type, impl common.IBaseManager) error {
 	return m.container.Register(ifaceType, impl)
 }
 
+// RegisterNamedByType 按接口类型和名称注册具名实现
+func (m *ManagerContainer) RegisterNamedByType(ifaceType reflect.Type, name string, impl common.IBaseManager) error {
+	return m.container.RegisterNamed(ifaceType, name, impl)
+}
+
+// ReplaceByType 按接口类型替换
+func (m *ManagerContainer) ReplaceByType(ifaceType reflect.Type, impl common.IBaseManager) error {
+	return m.container.Replace(ifaceType, impl)
+}
+
 // GetByType 按接口类型获取（返回单例）
 func (m *ManagerContainer) GetByType(ifaceType reflect.Type) common.IBaseManager {
 	return m.container.GetByType(ifaceType)
 }
 
+// MarkDisabled 标记接口类型对应的管理器已被禁用，注入时返回包含 reason 的错误
+func (m *ManagerContainer) MarkDisabled(ifaceType reflect.Type, reason string) {
+	m.disabled[ifaceType] = reason
+}
+
+// DisabledReason 返回接口类型对应的管理器被禁用的原因
+func (m *ManagerContainer) DisabledReason(ifaceType reflect.Type) (string, bool) {
+	reason, ok := m.disabled[ifaceType]
+	return reason, ok
+}
+
+// notFoundError 返回管理器缺失时的依赖错误，被禁用的管理器附带禁用原因
+func (m *ManagerContainer) notFoundError(fieldType reflect.Type) *DependencyNotFoundError {
+	err := &DependencyNotFoundError{
+		FieldType:     fieldType,
+		ContainerType: "Manager",
+	}
+	if reason, ok := m.DisabledReason(fieldType); ok {
+		err.Message = "manager is disabled (" + reason + ")"
+	}
+	return err
+}
+
 // GetAll 获取所有已注册的管理器
 func (m *ManagerContainer) GetAll() []common.IBaseManager {
 	return m.container.GetAll()
@@ -77,16 +125,19 @@ func (m *ManagerContainer) GetAllSorted() []common.IBaseManager {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (m *ManagerContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
+	return m.queryDependency(newTypeQuery(fieldType))
+}
+
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (m *ManagerContainer) queryDependency(q dependencyQuery) (interface{}, error) {
 	baseManagerType := reflect.TypeOf((*common.IBaseManager)(nil)).Elem()
-	if fieldType == baseManagerType || fieldType.Implements(baseManagerType) {
-		impl := m.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Manager",
-			}
-		}
-		return impl, nil
+	if !q.matches(baseManagerType) {
+		return nil, nil
+	}
+	dep, err := lookupDependency(m.container, q, "Manager")
+	var notFound *DependencyNotFoundError
+	if errors.As(err, &notFound) && q.kind == injectSingle && q.name == "" {
+		return nil, m.notFoundError(q.ifaceType)
 	}
-	return nil, nil
+	return dep, err
 }
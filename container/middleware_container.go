@@ -28,6 +28,19 @@ func RegisterMiddleware[T common.IBaseMiddleware](m *MiddlewareContainer, impl T
 	return m.RegisterByType(ifaceType, impl)
 }
 
+// RegisterMiddlewareNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterMiddlewareNamed[T common.IBaseMiddleware](m *MiddlewareContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return m.RegisterNamedByType(ifaceType, name, impl)
+}
+
+// RegisterMiddlewareConstructor 泛型注册函数，按接口类型注册构造函数
+// 构造函数的参数在依赖注入时解析，如 func(repo IUserRepository) IUserService，可附加返回 error
+func RegisterMiddlewareConstructor[T common.IBaseMiddleware](m *MiddlewareContainer, ctor interface{}) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return m.RegisterConstructorByType(ifaceType, ctor)
+}
+
 // GetMiddleware 按接口类型获取
 func GetMiddleware[T common.IBaseMiddleware](m *MiddlewareContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -44,7 +57,9 @@ func GetMiddleware[T common.IBaseMiddleware](m *MiddlewareContainer) (T, error)
 
 // InjectAll 执行依赖注入
 func (m *MiddlewareContainer) InjectAll() error {
-	m.checkManagerContainer("Middleware")
+	if err := m.checkManagerContainer("Middleware"); err != nil {
+		return err
+	}
 
 	if m.InjectableLayerContainer.base.container.IsInjected() {
 		return nil
@@ -56,36 +71,18 @@ func (m *MiddlewareContainer) InjectAll() error {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (m *MiddlewareContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
-	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
-	if fieldType == baseRepositoryType || fieldType.Implements(baseRepositoryType) {
-		return nil, &DependencyNotFoundError{
-			FieldType:     fieldType,
-			ContainerType: "Repository",
-			Message:       "Middleware cannot directly inject Repository, must access data through Service",
-		}
-	}
+	return m.queryDependency(newTypeQuery(fieldType))
+}
 
-	if dep, err := resolveDependencyFromManager(fieldType, m.managerContainer); dep != nil || err != nil {
-		return dep, err
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (m *MiddlewareContainer) queryDependency(q dependencyQuery) (interface{}, error) {
+	if err := rejectRepository(q, "Middleware"); err != nil {
+		return nil, err
 	}
 
-	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
-	if fieldType == baseServiceType || fieldType.Implements(baseServiceType) {
-		if m.serviceContainer == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		impl := m.serviceContainer.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		return impl, nil
+	if dep, err := resolveDependencyFromManager(q, m.managerContainer); dep != nil || err != nil {
+		return dep, err
 	}
 
-	return nil, nil
+	return resolveDependencyFromService(q, m.serviceContainer)
 }
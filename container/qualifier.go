@@ -0,0 +1,190 @@
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// injectKind 注入方式
+type injectKind int
+
+const (
+	injectSingle injectKind = iota // 单个实例
+	injectSlice                    // []T：接口的所有实现
+	injectMap                      // map[string]T：接口的所有实现，按名称索引
+)
+
+// dependencyQuery 依赖查询，由字段类型和 inject 标签解析得到
+type dependencyQuery struct {
+	fieldType reflect.Type // 字段类型
+	ifaceType reflect.Type // 依赖的接口类型（切片、map 字段为元素类型）
+	name      string       // 限定名称（inject:"name=xxx"），为空表示默认实现
+	kind      injectKind
+	optional  bool         // inject:"optional"：未注册时保持零值
+	lazyType  reflect.Type // Lazy[T] 字段的类型，非延迟依赖为 nil
+}
+
+// newTypeQuery 按字段类型创建查询：[]T 和 map[string]T 查询 T 的所有实现，其余类型查询默认实现；
+// Lazy[T] 按 T 查询并记录延迟依赖类型
+func newTypeQuery(fieldType reflect.Type) dependencyQuery {
+	if fieldType.Implements(lazyDependencyType) {
+		target := reflect.Zero(fieldType).Interface().(lazyDependency).lazyTarget()
+		q := newTypeQuery(target)
+		q.lazyType = fieldType
+		return q
+	}
+
+	q := dependencyQuery{fieldType: fieldType, ifaceType: fieldType, kind: injectSingle}
+	switch fieldType.Kind() {
+	case reflect.Slice:
+		q.kind, q.ifaceType = injectSlice, fieldType.Elem()
+	case reflect.Map:
+		if fieldType.Key().Kind() == reflect.String {
+			q.kind, q.ifaceType = injectMap, fieldType.Elem()
+		}
+	}
+	return q
+}
+
+// parseInjectTag 解析字段的 inject 标签
+// 支持的选项（逗号分隔）：
+//   - name=xxx：按名称注入指定实现，不能用于切片和 map 字段
+//   - optional：依赖未注册（或管理器被禁用）时保持零值，不报错
+func parseInjectTag(field reflect.StructField) (dependencyQuery, error) {
+	q := newTypeQuery(field.Type)
+	tag := field.Tag.Get("inject")
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case opt == "":
+		case opt == "optional":
+			q.optional = true
+		case strings.HasPrefix(opt, "name="):
+			q.name = strings.TrimSpace(strings.TrimPrefix(opt, "name="))
+			if q.name == "" {
+				return q, fmt.Errorf("empty name qualifier in inject tag %q", tag)
+			}
+		default:
+			return q, fmt.Errorf("unknown option %q in inject tag %q", opt, tag)
+		}
+	}
+	if q.name != "" && q.kind != injectSingle {
+		return q, fmt.Errorf("name qualifier cannot be used on %s field", field.Type.Kind())
+	}
+	return q, nil
+}
+
+// matches 判断查询的接口类型是否属于 baseType 所在的层
+func (q dependencyQuery) matches(baseType reflect.Type) bool {
+	return q.ifaceType == baseType || q.ifaceType.Implements(baseType)
+}
+
+// qualifiedName 返回接口类型和具名实现名称的描述，如 IPaymentGateway(name=stripe)
+func qualifiedName(ifaceType reflect.Type, name string) string {
+	if name == "" {
+		return ifaceType.String()
+	}
+	return fmt.Sprintf("%s(name=%s)", ifaceType, name)
+}
+
+// lazy 判断是否为 Lazy[T] 延迟依赖
+func (q dependencyQuery) lazy() bool {
+	return q.lazyType != nil
+}
+
+// notFound 返回查询未命中时的依赖错误
+func (q dependencyQuery) notFound(containerType string) *DependencyNotFoundError {
+	err := &DependencyNotFoundError{
+		FieldType:     q.ifaceType,
+		ContainerType: containerType,
+	}
+	if q.name != "" {
+		err.Message = fmt.Sprintf("no implementation named %q", q.name)
+	}
+	return err
+}
+
+// lookupDependency 在类型化容器中按查询解析依赖
+//   - 单个实例：优先使用默认实现；没有默认实现且只有一个具名实现时使用该实现，有多个时返回 AmbiguousMatchError
+//   - 限定名称：使用该名称的实现
+//   - 切片：所有实现（默认实现在前，具名实现按注册顺序），没有实现时为空切片
+//   - map：所有实现，具名实现以注册名称为键，默认实现以组件名称为键
+func lookupDependency[T any](c *TypedContainer[T], q dependencyQuery, containerType string) (interface{}, error) {
+	switch q.kind {
+	case injectSlice:
+		entries := c.entriesOf(q.ifaceType)
+		slice := reflect.MakeSlice(q.fieldType, 0, len(entries))
+		for _, entry := range entries {
+			slice = reflect.Append(slice, reflect.ValueOf(entry.impl))
+		}
+		return slice.Interface(), nil
+	case injectMap:
+		entries := c.entriesOf(q.ifaceType)
+		m := reflect.MakeMapWithSize(q.fieldType, len(entries))
+		for _, entry := range entries {
+			m.SetMapIndex(reflect.ValueOf(entry.name).Convert(q.fieldType.Key()), reflect.ValueOf(entry.impl))
+		}
+		return m.Interface(), nil
+	}
+
+	if q.name != "" {
+		impl, ok := c.GetNamed(q.ifaceType, q.name)
+		if !ok {
+			return nil, q.notFound(containerType)
+		}
+		return impl, nil
+	}
+
+	impl, ok := c.getDefault(q.ifaceType)
+	if ok {
+		return impl, nil
+	}
+	named := c.namedOf(q.ifaceType)
+	switch len(named) {
+	case 0:
+		return nil, q.notFound(containerType)
+	case 1:
+		return named[0].impl, nil
+	default:
+		names := make([]string, 0, len(named))
+		for _, entry := range named {
+			names = append(names, entry.name)
+		}
+		return nil, &AmbiguousMatchError{
+			FieldType:  q.ifaceType,
+			Candidates: names,
+		}
+	}
+}
+
+// queryNames 返回查询命中的实现名称（默认实现为空字符串，含尚未调用的构造函数），单个实例查询未命中时 found 为 false；
+// 多个具名实现且未指定名称时无法确定命中哪一个，返回 nil 和 true（由注入时报告 AmbiguousMatchError）
+func queryNames[T any](c *TypedContainer[T], q dependencyQuery) (names []string, found bool) {
+	if q.kind != injectSingle {
+		if c.hasDefault(q.ifaceType) {
+			names = append(names, "")
+		}
+		for _, entry := range c.namedOf(q.ifaceType) {
+			names = append(names, entry.name)
+		}
+		return names, true
+	}
+
+	if q.name != "" {
+		_, ok := c.GetNamed(q.ifaceType, q.name)
+		return []string{q.name}, ok
+	}
+	if c.hasDefault(q.ifaceType) {
+		return []string{""}, true
+	}
+	named := c.namedOf(q.ifaceType)
+	switch len(named) {
+	case 0:
+		return nil, false
+	case 1:
+		return []string{named[0].name}, true
+	default:
+		return nil, true
+	}
+}
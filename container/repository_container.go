@@ -32,6 +32,19 @@ func RegisterRepository[T common.IBaseRepository](r *RepositoryContainer, impl T
 	return r.RegisterByType(ifaceType, impl)
 }
 
+// RegisterRepositoryNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterRepositoryNamed[T common.IBaseRepository](r *RepositoryContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return r.RegisterNamedByType(ifaceType, name, impl)
+}
+
+// RegisterRepositoryConstructor 泛型注册函数，按接口类型注册构造函数
+// 构造函数的参数在依赖注入时解析，如 func(repo IUserRepository) IUserService，可附加返回 error
+func RegisterRepositoryConstructor[T common.IBaseRepository](r *RepositoryContainer, ctor interface{}) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return r.RegisterConstructorByType(ifaceType, ctor)
+}
+
 // GetRepository 按接口类型获取
 func GetRepository[T common.IBaseRepository](r *RepositoryContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -46,15 +59,41 @@ func GetRepository[T common.IBaseRepository](r *RepositoryContainer) (T, error)
 	return impl.(T), nil
 }
 
+// ReplaceRepository 泛型替换函数，按接口类型替换已注册的实现（需在依赖注入之前调用）
+func ReplaceRepository[T common.IBaseRepository](r *RepositoryContainer, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return r.ReplaceByType(ifaceType, impl)
+}
+
 // RegisterByType 按接口类型注册
 func (r *RepositoryContainer) RegisterByType(ifaceType reflect.Type, impl common.IBaseRepository) error {
 	return r.base.container.Register(ifaceType, impl)
 }
 
+// RegisterNamedByType 按接口类型和名称注册具名实现
+func (r *RepositoryContainer) RegisterNamedByType(ifaceType reflect.Type, name string, impl common.IBaseRepository) error {
+	return r.base.container.RegisterNamed(ifaceType, name, impl)
+}
+
+// RegisterConstructorByType 按接口类型注册构造函数
+func (r *RepositoryContainer) RegisterConstructorByType(ifaceType reflect.Type, ctor interface{}) error {
+	return r.base.container.RegisterConstructor(ifaceType, ctor)
+}
+
+// ConstructorParamTypes 返回所有构造函数参数依赖的接口类型（切片、map 参数返回元素类型）
+func (r *RepositoryContainer) ConstructorParamTypes() []reflect.Type {
+	return r.base.container.constructorParamTypes()
+}
+
+// ReplaceByType 按接口类型替换
+func (r *RepositoryContainer) ReplaceByType(ifaceType reflect.Type, impl common.IBaseRepository) error {
+	return r.base.container.Replace(ifaceType, impl)
+}
+
 // InjectAll 执行依赖注入
 func (r *RepositoryContainer) InjectAll() error {
 	if r.managerContainer == nil {
-		panic(&ManagerContainerNotSetError{Layer: "Repository"})
+		return &ManagerContainerNotSetError{Layer: "Repository"}
 	}
 
 	if r.base.container.IsInjected() {
@@ -96,13 +135,14 @@ func (r *RepositoryContainer) SetManagerContainer(container *ManagerContainer) {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (r *RepositoryContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
-	if dep, err := resolveDependencyFromManager(fieldType, r.managerContainer); dep != nil || err != nil {
-		return dep, err
-	}
+	return r.queryDependency(newTypeQuery(fieldType))
+}
 
-	if dep, err := resolveDependencyFromEntity(fieldType, r.entityContainer); dep != nil || err != nil {
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (r *RepositoryContainer) queryDependency(q dependencyQuery) (interface{}, error) {
+	if dep, err := resolveDependencyFromManager(q, r.managerContainer); dep != nil || err != nil {
 		return dep, err
 	}
 
-	return nil, nil
+	return resolveDependencyFromEntity(q, r.entityContainer)
 }
@@ -33,6 +33,19 @@ func RegisterScheduler[T common.IBaseScheduler](c *SchedulerContainer, impl T) e
 	return c.RegisterByType(ifaceType, impl)
 }
 
+// RegisterSchedulerNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterSchedulerNamed[T common.IBaseScheduler](c *SchedulerContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return c.RegisterNamedByType(ifaceType, name, impl)
+}
+
+// RegisterSchedulerConstructor 泛型注册函数，按接口类型注册构造函数
+// 构造函数的参数在依赖注入时解析，如 func(repo IUserRepository) IUserService，可附加返回 error
+func RegisterSchedulerConstructor[T common.IBaseScheduler](c *SchedulerContainer, ctor interface{}) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return c.RegisterConstructorByType(ifaceType, ctor)
+}
+
 // GetScheduler 按接口类型获取
 func GetScheduler[T common.IBaseScheduler](c *SchedulerContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -49,7 +62,9 @@ func GetScheduler[T common.IBaseScheduler](c *SchedulerContainer) (T, error) {
 
 // InjectAll 执行依赖注入
 func (c *SchedulerContainer) InjectAll() error {
-	c.checkManagerContainer("Scheduler")
+	if err := c.checkManagerContainer("Scheduler"); err != nil {
+		return err
+	}
 
 	if c.InjectableLayerContainer.base.container.IsInjected() {
 		return nil
@@ -61,36 +76,18 @@ func (c *SchedulerContainer) InjectAll() error {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (c *SchedulerContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
-	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
-	if fieldType == baseRepositoryType || fieldType.Implements(baseRepositoryType) {
-		return nil, &DependencyNotFoundError{
-			FieldType:     fieldType,
-			ContainerType: "Repository",
-			Message:       "Scheduler cannot directly inject Repository, must access data through Service",
-		}
-	}
+	return c.queryDependency(newTypeQuery(fieldType))
+}
 
-	if dep, err := resolveDependencyFromManager(fieldType, c.managerContainer); dep != nil || err != nil {
-		return dep, err
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (c *SchedulerContainer) queryDependency(q dependencyQuery) (interface{}, error) {
+	if err := rejectRepository(q, "Scheduler"); err != nil {
+		return nil, err
 	}
 
-	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
-	if fieldType == baseServiceType || fieldType.Implements(baseServiceType) {
-		if c.serviceContainer == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		impl := c.serviceContainer.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		return impl, nil
+	if dep, err := resolveDependencyFromManager(q, c.managerContainer); dep != nil || err != nil {
+		return dep, err
 	}
 
-	return nil, nil
+	return resolveDependencyFromService(q, c.serviceContainer)
 }
@@ -33,6 +33,19 @@ func RegisterService[T common.IBaseService](s *ServiceContainer, impl T) error {
 	return s.RegisterByType(ifaceType, impl)
 }
 
+// RegisterServiceNamed 泛型注册函数，按接口类型和名称注册具名实现（同一接口可注册多个实现）
+func RegisterServiceNamed[T common.IBaseService](s *ServiceContainer, name string, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return s.RegisterNamedByType(ifaceType, name, impl)
+}
+
+// RegisterServiceConstructor 泛型注册函数，按接口类型注册构造函数
+// 构造函数的参数在依赖注入时解析，如 func(repo IUserRepository) IUserService，可附加返回 error
+func RegisterServiceConstructor[T common.IBaseService](s *ServiceContainer, ctor interface{}) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return s.RegisterConstructorByType(ifaceType, ctor)
+}
+
 // GetService 按接口类型获取
 func GetService[T common.IBaseService](s *ServiceContainer) (T, error) {
 	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
@@ -47,53 +60,129 @@ func GetService[T common.IBaseService](s *ServiceContainer) (T, error) {
 	return impl.(T), nil
 }
 
+// ReplaceService 泛型替换函数，按接口类型替换已注册的实现（需在依赖注入之前调用）
+func ReplaceService[T common.IBaseService](s *ServiceContainer, impl T) error {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	return s.ReplaceByType(ifaceType, impl)
+}
+
 // RegisterByType 按接口类型注册
 func (s *ServiceContainer) RegisterByType(ifaceType reflect.Type, impl common.IBaseService) error {
 	return s.base.container.Register(ifaceType, impl)
 }
 
+// RegisterNamedByType 按接口类型和名称注册具名实现
+func (s *ServiceContainer) RegisterNamedByType(ifaceType reflect.Type, name string, impl common.IBaseService) error {
+	return s.base.container.RegisterNamed(ifaceType, name, impl)
+}
+
+// RegisterConstructorByType 按接口类型注册构造函数
+func (s *ServiceContainer) RegisterConstructorByType(ifaceType reflect.Type, ctor interface{}) error {
+	return s.base.container.RegisterConstructor(ifaceType, ctor)
+}
+
+// ConstructorParamTypes 返回所有构造函数参数依赖的接口类型（切片、map 参数返回元素类型）
+func (s *ServiceContainer) ConstructorParamTypes() []reflect.Type {
+	return s.base.container.constructorParamTypes()
+}
+
+// ReplaceByType 按接口类型替换
+func (s *ServiceContainer) ReplaceByType(ifaceType reflect.Type, impl common.IBaseService) error {
+	return s.base.container.Replace(ifaceType, impl)
+}
+
 // InjectAll 执行依赖注入
 func (s *ServiceContainer) InjectAll() error {
 	if s.managerContainer == nil {
-		panic(&ManagerContainerNotSetError{Layer: "Service"})
+		return &ManagerContainerNotSetError{Layer: "Service"}
 	}
 
 	if s.base.container.IsInjected() {
 		return nil
 	}
 
+	// 存在缺失的服务依赖时无法排序，仍按任意顺序注入所有服务，
+	// 一次性返回全部错误（注入时同样会报告缺失的服务依赖）
+	var sortedKeys []serviceKey
 	graph, err := s.buildDependencyGraph()
-	if err != nil {
-		return fmt.Errorf("build dependency graph failed: %w", err)
-	}
-
-	sortedTypes, err := topologicalSortByInterfaceType(graph)
-	if err != nil {
-		return fmt.Errorf("topological sort failed: %w", err)
+	if err == nil {
+		sortedKeys, err = graph.sort()
+		if err != nil {
+			return fmt.Errorf("topological sort failed: %w", err)
+		}
+	} else {
+		sortedKeys = graph.nodes()
 	}
 
 	s.base.sources = s.base.buildSources(s, s.managerContainer, s.repositoryContainer)
 	resolver := NewGenericDependencyResolver(s.base.sources...)
 
-	for _, ifaceType := range sortedTypes {
-		svc := s.GetByType(ifaceType)
+	// 按拓扑顺序调用构造函数，保证构造时参数依赖的服务已构造
+	pending := s.base.container.constructors(true)
+
+	var lazies []lazyDependency
+	var errs []error
+	for _, key := range sortedKeys {
+		if ctor, ok := pending[key.ifaceType]; ok && key.name == "" {
+			ctorLazies, err := s.base.container.construct(key.ifaceType, ctor, resolver)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			lazies = append(lazies, ctorLazies...)
+		}
+		svc := s.getByKey(key)
 		if svc != nil {
-			if err := injectDependencies(svc, resolver); err != nil {
-				return err
+			if err := injectInstance(svc, resolver); err != nil {
+				errs = append(errs, err)
 			}
-			verifyInjectTags(svc)
+			lazies = append(lazies, lazyFields(svc)...)
 		}
 	}
+	if len(errs) > 0 {
+		return joinErrors(errs)
+	}
+	// 所有服务就绪后再检查 Lazy 依赖（Lazy 依赖不参与拓扑排序，目标可能在依赖方之后构造）
+	if err := checkLazyDependencies(lazies); err != nil {
+		return err
+	}
 
 	s.base.container.setInjected(true)
 	return nil
 }
 
-// buildDependencyGraph 构建服务依赖图
-func (s *ServiceContainer) buildDependencyGraph() (map[reflect.Type][]reflect.Type, error) {
-	graph := make(map[reflect.Type][]reflect.Type)
+// serviceKey 服务依赖图节点：接口类型和具名实现的名称（默认实现为空）
+type serviceKey struct {
+	ifaceType reflect.Type
+	name      string
+}
+
+// String 返回节点描述，如 IPaymentGateway 或 IPaymentGateway(name=stripe)
+func (k serviceKey) String() string {
+	return qualifiedName(k.ifaceType, k.name)
+}
+
+// getByKey 按依赖图节点获取服务
+func (s *ServiceContainer) getByKey(key serviceKey) common.IBaseService {
+	if key.name == "" {
+		return s.GetByType(key.ifaceType)
+	}
+	svc, _ := s.base.container.GetNamed(key.ifaceType, key.name)
+	return svc
+}
+
+// buildDependencyGraph 构建服务依赖图（含具名实现）
+// 依赖未注册的服务时返回所有缺失依赖的错误，依赖图仍包含所有服务（不含缺失的边）；
+// 多个具名实现无法确定注入哪一个时不加边，由注入时报告 AmbiguousMatchError
+func (s *ServiceContainer) buildDependencyGraph() (*dependencyGraph[serviceKey], error) {
+	graph := newDependencyGraph[serviceKey]()
+	var errs []error
+	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
+
+	s.base.container.rangeEntries(func(ifaceType reflect.Type, name string, svc common.IBaseService) bool {
+		key := serviceKey{ifaceType: ifaceType, name: name}
+		graph.addNode(key)
 
-	s.base.container.RangeItems(func(ifaceType reflect.Type, svc common.IBaseService) bool {
 		val := reflect.ValueOf(svc)
 		if val.Kind() == reflect.Ptr {
 			val = val.Elem()
@@ -103,8 +192,6 @@ func (s *ServiceContainer) buildDependencyGraph() (map[reflect.Type][]reflect.Ty
 			return true
 		}
 
-		var deps []reflect.Type
-
 		typ := val.Type()
 		for i := 0; i < val.NumField(); i++ {
 			field := typ.Field(i)
@@ -113,23 +200,63 @@ func (s *ServiceContainer) buildDependencyGraph() (map[reflect.Type][]reflect.Ty
 				continue
 			}
 
-			fieldType := field.Type
-			if s.isBaseServiceType(fieldType) {
-				if s.GetByType(fieldType) == nil {
-					panic(&DependencyNotFoundError{
-						FieldType:     fieldType,
-						ContainerType: "Service",
-					})
-				}
-				deps = append(deps, fieldType)
+			q, err := parseInjectTag(field)
+			if err != nil || !q.matches(baseServiceType) {
+				continue
 			}
-		}
 
-		graph[ifaceType] = deps
+			if err := s.addDependencyEdges(graph, key, q, extractNameFromType(typ), field.Name); err != nil {
+				errs = append(errs, err)
+			}
+		}
 		return true
 	})
 
-	return graph, nil
+	// 构造函数参数依赖的服务（已调用的构造函数同样保留依赖边，供启动顺序使用）
+	for ifaceType, ctor := range s.base.container.constructors(false) {
+		key := serviceKey{ifaceType: ifaceType}
+		graph.addNode(key)
+		for i, q := range ctor.params {
+			if !q.matches(baseServiceType) {
+				continue
+			}
+			if err := s.addDependencyEdges(graph, key, q, ctor.name, ctor.paramName(i)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return graph, joinErrors(errs)
+}
+
+// addDependencyEdges 添加 key 依赖查询命中的服务的边
+// optional 依赖未注册时不报错；Lazy 依赖只检查是否注册，不加边（首次使用时才解析，不影响注入顺序）
+func (s *ServiceContainer) addDependencyEdges(graph *dependencyGraph[serviceKey], key serviceKey, q dependencyQuery, instanceName, fieldName string) error {
+	deps, found := s.dependencyKeys(q)
+	if !found {
+		if q.optional {
+			return nil
+		}
+		notFound := q.notFound("Service")
+		notFound.InstanceName = instanceName
+		notFound.FieldName = fieldName
+		return notFound
+	}
+	if !q.lazy() {
+		for _, dep := range deps {
+			graph.addEdge(key, dep, fieldName)
+		}
+	}
+	return nil
+}
+
+// dependencyKeys 返回查询命中的服务节点，单个实例查询未命中时 found 为 false
+func (s *ServiceContainer) dependencyKeys(q dependencyQuery) (keys []serviceKey, found bool) {
+	names, found := queryNames(s.base.container, q)
+	for _, name := range names {
+		keys = append(keys, serviceKey{ifaceType: q.ifaceType, name: name})
+	}
+	return keys, found
 }
 
 // isBaseServiceType 检查类型是否为服务类型
@@ -159,14 +286,14 @@ func (s *ServiceContainer) GetAllTopological() ([]common.IBaseService, error) {
 		return nil, fmt.Errorf("build dependency graph failed: %w", err)
 	}
 
-	sortedTypes, err := topologicalSortByInterfaceType(graph)
+	sortedKeys, err := graph.sort()
 	if err != nil {
 		return nil, fmt.Errorf("topological sort failed: %w", err)
 	}
 
-	items := make([]common.IBaseService, 0, len(sortedTypes))
-	for _, ifaceType := range sortedTypes {
-		if svc := s.GetByType(ifaceType); svc != nil {
+	items := make([]common.IBaseService, 0, len(sortedKeys))
+	for _, key := range sortedKeys {
+		if svc := s.getByKey(key); svc != nil {
 			items = append(items, svc)
 		}
 	}
@@ -190,39 +317,18 @@ func (s *ServiceContainer) SetManagerContainer(container *ManagerContainer) {
 
 // GetDependency 根据类型获取依赖实例（实现ContainerSource接口）
 func (s *ServiceContainer) GetDependency(fieldType reflect.Type) (interface{}, error) {
-	if dep, err := resolveDependencyFromManager(fieldType, s.managerContainer); dep != nil || err != nil {
-		return dep, err
-	}
+	return s.queryDependency(newTypeQuery(fieldType))
+}
 
-	baseServiceType := reflect.TypeOf((*common.IBaseService)(nil)).Elem()
-	if fieldType == baseServiceType || fieldType.Implements(baseServiceType) {
-		impl := s.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Service",
-			}
-		}
-		return impl, nil
+// queryDependency 按查询获取依赖实例，支持限定名称、切片和 map 注入
+func (s *ServiceContainer) queryDependency(q dependencyQuery) (interface{}, error) {
+	if dep, err := resolveDependencyFromManager(q, s.managerContainer); dep != nil || err != nil {
+		return dep, err
 	}
 
-	baseRepositoryType := reflect.TypeOf((*common.IBaseRepository)(nil)).Elem()
-	if fieldType == baseRepositoryType || fieldType.Implements(baseRepositoryType) {
-		if s.repositoryContainer == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Repository",
-			}
-		}
-		impl := s.repositoryContainer.GetByType(fieldType)
-		if impl == nil {
-			return nil, &DependencyNotFoundError{
-				FieldType:     fieldType,
-				ContainerType: "Repository",
-			}
-		}
-		return impl, nil
+	if dep, err := resolveDependencyFromService(q, s); dep != nil || err != nil {
+		return dep, err
 	}
 
-	return nil, nil
+	return resolveDependencyFromRepository(q, s.repositoryContainer)
 }
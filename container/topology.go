@@ -2,21 +2,80 @@ package container
 
 import (
 	"container/list"
+	"fmt"
 	"reflect"
+	"sort"
 )
 
 // topologicalSortByInterfaceType 使用 Kahn 算法进行拓扑排序（接口类型版本）
 // graph: 依赖图，key 和 value 都是接口类型
 // 返回: 拓扑排序后的接口类型列表
 func topologicalSortByInterfaceType(graph map[reflect.Type][]reflect.Type) ([]reflect.Type, error) {
-	inDegree := make(map[reflect.Type]int)
-	adjList := make(map[reflect.Type][]reflect.Type)
+	g := newDependencyGraph[reflect.Type]()
+	for node, deps := range graph {
+		g.addNode(node)
+		for _, dep := range deps {
+			g.addEdge(node, dep, "")
+		}
+	}
+	return g.sort()
+}
+
+// dependencyGraph 依赖图：deps[from] 为 from 依赖的节点，fields 记录产生依赖的字段名
+// 节点名称使用 fmt.Sprint 输出（用于错误信息和排序）
+type dependencyGraph[K comparable] struct {
+	deps   map[K][]K
+	fields map[K]map[K]string
+}
+
+// newDependencyGraph 创建依赖图
+func newDependencyGraph[K comparable]() *dependencyGraph[K] {
+	return &dependencyGraph[K]{
+		deps:   make(map[K][]K),
+		fields: make(map[K]map[K]string),
+	}
+}
+
+// addNode 添加节点
+func (g *dependencyGraph[K]) addNode(node K) {
+	if _, ok := g.deps[node]; !ok {
+		g.deps[node] = nil
+	}
+}
+
+// addEdge 添加 from 依赖 to 的边，field 为产生依赖的字段名（同一条边保留第一个字段名）
+func (g *dependencyGraph[K]) addEdge(from, to K, field string) {
+	g.addNode(from)
+	g.addNode(to)
+	g.deps[from] = append(g.deps[from], to)
+	if g.fields[from] == nil {
+		g.fields[from] = make(map[K]string)
+	}
+	if _, ok := g.fields[from][to]; !ok {
+		g.fields[from][to] = field
+	}
+}
+
+// nodes 返回所有节点
+func (g *dependencyGraph[K]) nodes() []K {
+	nodes := make([]K, 0, len(g.deps))
+	for node := range g.deps {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// sort 使用 Kahn 算法进行拓扑排序，被依赖的节点在前
+// 存在循环依赖时返回 CircularDependencyError，包含各个环的路径和字段名
+func (g *dependencyGraph[K]) sort() ([]K, error) {
+	inDegree := make(map[K]int)
+	adjList := make(map[K][]K)
 
-	for node := range graph {
+	for node := range g.deps {
 		inDegree[node] = 0
 	}
 
-	for node, deps := range graph {
+	for node, deps := range g.deps {
 		for _, dep := range deps {
 			adjList[dep] = append(adjList[dep], node)
 			inDegree[node]++
@@ -30,9 +89,9 @@ func topologicalSortByInterfaceType(graph map[reflect.Type][]reflect.Type) ([]re
 		}
 	}
 
-	var result []reflect.Type
+	var result []K
 	for queue.Len() > 0 {
-		node := queue.Remove(queue.Front()).(reflect.Type)
+		node := queue.Remove(queue.Front()).(K)
 		result = append(result, node)
 
 		for _, neighbor := range adjList[node] {
@@ -43,17 +102,134 @@ func topologicalSortByInterfaceType(graph map[reflect.Type][]reflect.Type) ([]re
 		}
 	}
 
-	if len(result) != len(graph) {
-		var remainingNodes []string
-		for node, degree := range inDegree {
-			if degree > 0 {
-				remainingNodes = append(remainingNodes, node.String())
+	if len(result) != len(g.deps) {
+		err := &CircularDependencyError{Cycles: g.cycles()}
+		if len(err.Cycles) > 0 {
+			err.Cycle = err.Cycles[0].Path
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// cycles 找出依赖图中的循环依赖
+// 使用 Tarjan 算法求强连通分量，每个分量中从名称最小的节点出发用 BFS 找出一条最短的环；
+// 只是位于环下游的节点不会出现在结果中。结果按首节点名称排序
+func (g *dependencyGraph[K]) cycles() []DependencyCycle {
+	label := func(node K) string { return fmt.Sprint(node) }
+	sortNodes := func(nodes []K) {
+		sort.Slice(nodes, func(i, j int) bool { return label(nodes[i]) < label(nodes[j]) })
+	}
+	neighbors := func(node K) []K {
+		seen := make(map[K]bool)
+		var result []K
+		for _, dep := range g.deps[node] {
+			if !seen[dep] {
+				seen[dep] = true
+				result = append(result, dep)
+			}
+		}
+		sortNodes(result)
+		return result
+	}
+
+	index := make(map[K]int)
+	low := make(map[K]int)
+	onStack := make(map[K]bool)
+	var stack []K
+	var sccs [][]K
+
+	var strongConnect func(v K)
+	strongConnect = func(v K) {
+		index[v] = len(index)
+		low[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range neighbors(v) {
+			if _, visited := index[w]; !visited {
+				strongConnect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
 			}
 		}
-		return nil, &CircularDependencyError{
-			Cycle: remainingNodes,
+
+		if low[v] == index[v] {
+			var scc []K
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sccs = append(sccs, scc)
 		}
 	}
 
-	return result, nil
+	nodes := g.nodes()
+	sortNodes(nodes)
+	for _, node := range nodes {
+		if _, visited := index[node]; !visited {
+			strongConnect(node)
+		}
+	}
+
+	var cycles []DependencyCycle
+	for _, scc := range sccs {
+		if path := g.shortestCycle(scc, neighbors, sortNodes); path != nil {
+			cycle := DependencyCycle{
+				Path:   make([]string, len(path)),
+				Fields: make([]string, len(path)),
+			}
+			for i, node := range path {
+				cycle.Path[i] = label(node)
+				cycle.Fields[i] = g.fields[node][path[(i+1)%len(path)]]
+			}
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Path[0] < cycles[j].Path[0] })
+	return cycles
+}
+
+// shortestCycle 在强连通分量中找出经过名称最小节点的最短环，分量不含环（单节点且无自环）时返回 nil
+func (g *dependencyGraph[K]) shortestCycle(scc []K, neighbors func(K) []K, sortNodes func([]K)) []K {
+	inSCC := make(map[K]bool, len(scc))
+	for _, node := range scc {
+		inSCC[node] = true
+	}
+	sortNodes(scc)
+	start := scc[0]
+
+	prev := map[K]K{}
+	visited := map[K]bool{start: true}
+	queue := []K{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range neighbors(v) {
+			if !inSCC[w] {
+				continue
+			}
+			if w == start {
+				path := []K{v}
+				for path[0] != start {
+					path = append([]K{prev[path[0]]}, path...)
+				}
+				return path
+			}
+			if !visited[w] {
+				visited[w] = true
+				prev[w] = v
+				queue = append(queue, w)
+			}
+		}
+	}
+	return nil
 }
@@ -25,3 +25,39 @@ func Build(driver string, filePath string) (IConfigManager, error) {
 		return nil, fmt.Errorf("unsupported config driver: '%s'", driver)
 	}
 }
+
+// NewMemoryConfigManager 基于内存中的配置数据创建配置管理器
+// 适用于测试或由代码组装配置的场景；data 会被深拷贝，创建后修改 data 不影响管理器
+func NewMemoryConfigManager(data map[string]any) IConfigManager {
+	configData, _ := copyConfigValue(data).(map[string]any)
+	if configData == nil {
+		configData = map[string]any{}
+	}
+	return &baseConfigManager{
+		managerName: "ConfigMemoryManager",
+		configData:  expandEnvVars(configData).(map[string]any),
+	}
+}
+
+// copyConfigValue 深拷贝配置数据中的 map 和切片
+func copyConfigValue(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		if v == nil {
+			return nil
+		}
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = copyConfigValue(item)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, item := range v {
+			s[i] = copyConfigValue(item)
+		}
+		return s
+	default:
+		return val
+	}
+}
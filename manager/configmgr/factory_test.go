@@ -87,3 +87,33 @@ func TestBuild(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to parse json")
 	})
 }
+
+func TestNewMemoryConfigManager(t *testing.T) {
+	t.Setenv("LITE_TEST_DB_DSN", "file::memory:")
+
+	data := map[string]any{
+		"server": map[string]any{"port": 8080},
+		"database": map[string]any{
+			"sqlite_config": map[string]any{"dsn": "${LITE_TEST_DB_DSN}"},
+		},
+	}
+	mgr := NewMemoryConfigManager(data)
+	assert.Equal(t, "ConfigMemoryManager", mgr.ManagerName())
+
+	port, err := mgr.Get("server.port")
+	assert.NoError(t, err)
+	assert.Equal(t, 8080, port)
+
+	dsn, err := mgr.Get("database.sqlite_config.dsn")
+	assert.NoError(t, err)
+	assert.Equal(t, "file::memory:", dsn)
+
+	// 创建后修改原始数据不影响管理器
+	data["server"].(map[string]any)["port"] = 9090
+	port, _ = mgr.Get("server.port")
+	assert.Equal(t, 8080, port)
+	assert.Equal(t, "${LITE_TEST_DB_DSN}", data["database"].(map[string]any)["sqlite_config"].(map[string]any)["dsn"])
+
+	empty := NewMemoryConfigManager(nil)
+	assert.False(t, empty.Has("server"))
+}
@@ -211,8 +211,8 @@ func argsToFields(args ...any) []zap.Field {
 }
 
 var (
-	supportsColor bool
-	colorChecked  bool
+	supportsColor    bool
+	colorSupportOnce sync.Once // 多个 Engine 并发初始化日志器时只检测一次
 )
 
 // buildConsoleCore 构建控制台日志输出核心
@@ -224,10 +224,9 @@ func buildConsoleCore(cfg *LogLevelConfig) (zapcore.Core, error) {
 		format = "gin"
 	}
 
-	if !colorChecked {
+	colorSupportOnce.Do(func() {
 		supportsColor = detectColorSupport()
-		colorChecked = true
-	}
+	})
 
 	useColor := cfg.Color && supportsColor
 
@@ -375,10 +374,9 @@ func detectColorSupport() bool {
 
 // customLevelEncoder 自定义日志级别编码器，支持彩色输出
 func customLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
-	if !colorChecked {
+	colorSupportOnce.Do(func() {
 		supportsColor = detectColorSupport()
-		colorChecked = true
-	}
+	})
 
 	const (
 		colorReset  = "\033[0m"
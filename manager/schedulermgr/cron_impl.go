@@ -25,6 +25,10 @@ type schedulerManagerImpl struct {
 	tasksMap  map[common.IBaseScheduler]*schedulerTask
 	mu        sync.RWMutex
 	loggerMgr loggermgr.ILoggerManager
+
+	running     int           // 正在执行的 OnTick 数量
+	runningIdle chan struct{} // Wait 期间创建，running 归零时关闭
+	runningMu   sync.Mutex
 }
 
 // NewSchedulerManagerCronImpl 创建 Cron 实现的调度管理器
@@ -208,7 +212,12 @@ func (s *schedulerManagerImpl) executeTask(task *schedulerTask, tickID int64) {
 		return
 	}
 
+	s.runningMu.Lock()
+	s.running++
+	s.runningMu.Unlock()
+
 	go func() {
+		defer s.tickDone()
 		defer task.execMutex.Unlock()
 		defer func() {
 			if r := recover(); r != nil {
@@ -232,5 +241,39 @@ func (s *schedulerManagerImpl) executeTask(task *schedulerTask, tickID int64) {
 	}()
 }
 
+// tickDone 标记一次 OnTick 执行完成
+func (s *schedulerManagerImpl) tickDone() {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	s.running--
+	if s.running == 0 && s.runningIdle != nil {
+		close(s.runningIdle)
+		s.runningIdle = nil
+	}
+}
+
+// Wait 实现 ITickWaiter，等待正在执行的 OnTick 全部完成
+func (s *schedulerManagerImpl) Wait(ctx context.Context) error {
+	s.runningMu.Lock()
+	if s.running == 0 {
+		s.runningMu.Unlock()
+		return nil
+	}
+	if s.runningIdle == nil {
+		s.runningIdle = make(chan struct{})
+	}
+	idle := s.runningIdle
+	s.runningMu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 var _ ISchedulerManager = (*schedulerManagerImpl)(nil)
+var _ ITickWaiter = (*schedulerManagerImpl)(nil)
 var _ common.IBaseManager = (*schedulerManagerImpl)(nil)
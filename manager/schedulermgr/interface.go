@@ -1,6 +1,8 @@
 package schedulermgr
 
 import (
+	"context"
+
 	"github.com/lite-lake/litecore-go/common"
 )
 
@@ -26,3 +28,10 @@ type ISchedulerManager interface {
 	// 返回: 注销错误
 	UnregisterScheduler(scheduler common.IBaseScheduler) error
 }
+
+// ITickWaiter 可选接口：支持等待正在执行的 OnTick 完成的定时任务管理器
+// Engine 在关闭阶段注销定时器后通过类型断言调用，未实现时不等待
+type ITickWaiter interface {
+	// Wait 等待正在执行的 OnTick 全部完成，ctx 到期时返回 ctx.Err()
+	Wait(ctx context.Context) error
+}
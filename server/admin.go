@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+)
+
+// adminEnabled 是否启用独立的管理端监听器
+func (e *Engine) adminEnabled() bool {
+	return e.serverConfig.Admin != nil && e.serverConfig.Admin.Enabled
+}
+
+// initAdminEngine 创建管理端 Gin 引擎和 HTTP 服务器
+// 管理端仅挂载系统路由和管理端控制器，不经过业务全局中间件
+func (e *Engine) initAdminEngine() {
+	e.adminEngine = gin.New()
+	e.installErrorHandling(e.adminEngine)
+
+	e.adminServer = &http.Server{
+		Addr:              e.serverConfig.Admin.Address(),
+		Handler:           e.adminEngine,
+		ReadTimeout:       e.serverConfig.ReadTimeout,
+		ReadHeaderTimeout: e.serverConfig.ReadHeaderTimeout,
+		IdleTimeout:       e.serverConfig.IdleTimeout,
+		MaxHeaderBytes:    e.serverConfig.MaxHeaderBytes,
+	}
+}
+
+// routerFor 返回控制器应挂载的 Gin 引擎
+// 启用管理端监听器时，实现 IAdminController 且 AdminOnly 返回 true 的控制器挂载到管理端
+func (e *Engine) routerFor(ctrl common.IBaseController) (*gin.Engine, bool) {
+	if !e.adminEnabled() {
+		return e.ginEngine, false
+	}
+	if admin, ok := ctrl.(common.IAdminController); ok && admin.AdminOnly() {
+		return e.adminEngine, true
+	}
+	return e.ginEngine, false
+}
+
+// startAdminServer 启动管理端 HTTP 服务器
+// 管理端监听器同样参与平滑重启的 socket 传递
+func (e *Engine) startAdminServer(errChan chan<- error) (net.Listener, error) {
+	ln, inherited, err := listen(listenerAdmin, "tcp", e.adminServer.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("admin server failed to start: %w", err)
+	}
+	e.logger().Info("Admin server listening", "addr", ln.Addr().String(), "inherited", inherited)
+
+	go func() {
+		if err := e.adminServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			e.logger().Error("Admin server error", "error", err)
+			errChan <- fmt.Errorf("admin server error: %w", err)
+		}
+	}()
+
+	return ln, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+type testAdminOnlyController struct {
+	name  string
+	route string
+}
+
+func (c *testAdminOnlyController) ControllerName() string { return c.name }
+func (c *testAdminOnlyController) GetRouter() string      { return c.route }
+func (c *testAdminOnlyController) AdminOnly() bool        { return true }
+func (c *testAdminOnlyController) Handle(ctx *gin.Context) {
+	ctx.String(http.StatusOK, c.name)
+}
+
+type iTestMetricsController interface{ common.IBaseController }
+type iTestPprofController interface{ common.IBaseController }
+
+// newAdminTestEngine 创建注册了管理端控制器的测试引擎
+func newAdminTestEngine(t *testing.T, adminEnabled bool, mode string) *Engine {
+	t.Helper()
+
+	engine := newRouteTestEngine(t)
+	engine.serverConfig.Mode = mode
+	engine.serverConfig.Admin.Enabled = adminEnabled
+	if adminEnabled {
+		engine.initAdminEngine()
+	}
+
+	_ = container.RegisterController[iTestMetricsController](engine.Controller, &testAdminOnlyController{
+		name: "MetricsController", route: "/metrics [GET]",
+	})
+	_ = container.RegisterController[iTestPprofController](engine.Controller, &testAdminOnlyController{
+		name: "PprofController", route: "/debug/pprof [GET]",
+	})
+	_ = container.RegisterController[iTestPlainController](engine.Controller, &testPlainController{})
+
+	engine.registerSystemRoutes()
+	if err := engine.registerControllers(); err != nil {
+		t.Fatalf("注册控制器失败: %v", err)
+	}
+	return engine
+}
+
+// serveStatus 返回请求指定路径的状态码
+func serveStatus(handler http.Handler, path string) int {
+	req, _ := http.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w.Code
+}
+
+// TestAdminListenerRouting 测试管理端监听器路由挂载
+func TestAdminListenerRouting(t *testing.T) {
+	t.Run("启用管理端_系统路由和管理端控制器仅挂载到管理端", func(t *testing.T) {
+		engine := newAdminTestEngine(t, true, "release")
+
+		for _, path := range []string{"/api/health", "/metrics", "/debug/pprof"} {
+			if code := serveStatus(engine.adminEngine, path); code != http.StatusOK {
+				t.Errorf("管理端 %s 期望 200, 实际 %d", path, code)
+			}
+			if code := serveStatus(engine.ginEngine, path); code != http.StatusNotFound {
+				t.Errorf("公共端口 %s 期望 404, 实际 %d", path, code)
+			}
+		}
+
+		if code := serveStatus(engine.ginEngine, "/api/public"); code != http.StatusOK {
+			t.Errorf("公共端口 /api/public 期望 200, 实际 %d", code)
+		}
+		if code := serveStatus(engine.adminEngine, "/api/public"); code != http.StatusNotFound {
+			t.Errorf("管理端 /api/public 期望 404, 实际 %d", code)
+		}
+	})
+
+	t.Run("未启用管理端_release模式不注册pprof", func(t *testing.T) {
+		engine := newAdminTestEngine(t, false, "release")
+
+		if engine.adminEngine != nil {
+			t.Fatal("未启用管理端时不应创建管理端引擎")
+		}
+		if code := serveStatus(engine.ginEngine, "/metrics"); code != http.StatusOK {
+			t.Errorf("/metrics 期望 200, 实际 %d", code)
+		}
+		if code := serveStatus(engine.ginEngine, "/debug/pprof"); code != http.StatusNotFound {
+			t.Errorf("/debug/pprof 期望 404, 实际 %d", code)
+		}
+	})
+
+	t.Run("未启用管理端_debug模式注册pprof", func(t *testing.T) {
+		engine := newAdminTestEngine(t, false, "debug")
+
+		if code := serveStatus(engine.ginEngine, "/debug/pprof"); code != http.StatusOK {
+			t.Errorf("/debug/pprof 期望 200, 实际 %d", code)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/lite-lake/litecore-go/container"
 	"github.com/lite-lake/litecore-go/logger"
@@ -50,123 +52,212 @@ func logPhaseEnd(log logger.ILogger, phase StartupPhase, msg string, extraFields
 	log.Info(msg, fields...)
 }
 
-// Initialize 初始化所有内置管理器并注册到容器中
-// 初始化顺序：config -> telemetry -> logger -> database -> cache -> lock -> limiter -> mq
-func Initialize(cfg *BuiltinConfig) (*container.ManagerContainer, error) {
+// Initialize 初始化所有内置管理器及 factories 中的自定义管理器并注册到容器中
+// 初始化顺序：config -> telemetry -> logger -> database -> cache -> lock -> limiter -> mq -> scheduler -> notification，
+// 自定义管理器按声明的依赖插入其中
+func Initialize(cfg *BuiltinConfig, factories ...*ManagerFactory) (*container.ManagerContainer, error) {
+	configManager, err := loadConfigManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return InitializeWithConfigManager(configManager, factories...)
+}
 
-	cntr := container.NewManagerContainer()
+// InitializeWithConfigManager 使用已创建的配置管理器初始化其余内置管理器及自定义管理器并注册到容器中
+// 适用于配置不来自文件的场景（如 configmgr.NewMemoryConfigManager）
+// 未经 Engine 调用时无法得知组件的注入需求，lazy 设置被忽略，启用的管理器全部构建
+func InitializeWithConfigManager(configManager configmgr.IConfigManager, factories ...*ManagerFactory) (*container.ManagerContainer, error) {
+	return initializeManagers(configManager, factories, nil, nil)
+}
 
+// loadConfigManager 按 BuiltinConfig 读取配置文件创建配置管理器
+func loadConfigManager(cfg *BuiltinConfig) (configmgr.IConfigManager, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configmgr: %w", err)
 	}
 
 	tempLogger := logger.NewDefaultLogger("Builtin")
-
-	logPhaseStart(tempLogger, PhaseConfig, "Starting to initialize builtin components")
 	logStartup(tempLogger, PhaseConfig, "Config file: "+cfg.FilePath)
 	logStartup(tempLogger, PhaseConfig, "Config driver: "+cfg.Driver)
 
-	// 1. 初始化配置管理器（必须最先初始化，其他管理器依赖它）
+	// 配置管理器必须最先初始化，其他管理器依赖它
 	configManager, err := configmgr.Build(cfg.Driver, cfg.FilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config manager: %w", err)
 	}
+	return configManager, nil
+}
+
+// initializeManagers 注册配置管理器并按依赖顺序构建其余管理器
+// required 判断延迟构建的管理器是否被组件需要，为 nil 时忽略 lazy 设置
+// onBuilt 在每个管理器构建完成后以名称和耗时回调，可为 nil
+func initializeManagers(
+	configManager configmgr.IConfigManager,
+	factories []*ManagerFactory,
+	required func(reflect.Type) bool,
+	onBuilt func(name string, d time.Duration),
+) (*container.ManagerContainer, error) {
+	if configManager == nil {
+		return nil, fmt.Errorf("config manager cannot be nil")
+	}
+
+	cntr := container.NewManagerContainer()
+	tempLogger := logger.NewDefaultLogger("Builtin")
+
+	logPhaseStart(tempLogger, PhaseConfig, "Starting to initialize builtin components")
+
+	// 注册配置管理器，其他管理器均依赖它
 	if err := container.RegisterManager[configmgr.IConfigManager](cntr, configManager); err != nil {
 		return nil, fmt.Errorf("failed to register config manager: %w", err)
 	}
 	logStartup(tempLogger, PhaseManagers, "Initialization complete: ConfigManager")
 
-	// 2. 初始化遥测管理器（依赖配置管理器）
-	telemetryMgr, err := telemetrymgr.BuildWithConfigProvider(configManager)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create telemetry manager: %w", err)
-	}
-	if err := container.RegisterManager[telemetrymgr.ITelemetryManager](cntr, telemetryMgr); err != nil {
-		return nil, fmt.Errorf("failed to register telemetry manager: %w", err)
+	// 按依赖顺序构建内置管理器和自定义管理器
+	all := append(builtinManagerFactories(), factories...)
+	if err := buildManagers(cntr, all, required, func(msg string) {
+		logStartup(tempLogger, PhaseManagers, msg)
+	}, onBuilt); err != nil {
+		return nil, err
 	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: TelemetryManager")
 
-	// 3. 初始化日志管理器（依赖配置管理器和遥测管理器）
-	loggerManager, err := loggermgr.BuildWithConfigProvider(configManager, telemetryMgr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create logger manager: %w", err)
-	}
-	if err := container.RegisterManager[loggermgr.ILoggerManager](cntr, loggerManager); err != nil {
-		return nil, fmt.Errorf("failed to register logger manager: %w", err)
-	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: LoggerManager")
+	logPhaseEnd(tempLogger, PhaseManagers, "Managers initialization complete", logger.F("count", cntr.Count()))
 
-	// 4. 初始化数据库管理器（依赖配置管理器）
-	databaseMgr, err := databasemgr.BuildWithConfigProvider(configManager, loggerManager, telemetryMgr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create database manager: %w", err)
-	}
-	if err := container.RegisterManager[databasemgr.IDatabaseManager](cntr, databaseMgr); err != nil {
-		return nil, fmt.Errorf("failed to register database manager: %w", err)
-	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: DatabaseManager")
+	return cntr, nil
+}
 
-	// 5. 初始化缓存管理器（依赖配置管理器）
-	cacheMgr, err := cachemgr.BuildWithConfigProvider(configManager, loggerManager, telemetryMgr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create cache manager: %w", err)
-	}
-	if err := container.RegisterManager[cachemgr.ICacheManager](cntr, cacheMgr); err != nil {
-		return nil, fmt.Errorf("failed to register cache manager: %w", err)
-	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: CacheManager")
+// builtinManagerFactories 返回内置管理器的工厂（配置管理器除外）
+func builtinManagerFactories() []*ManagerFactory {
+	return []*ManagerFactory{
+		// 遥测管理器（依赖配置管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (telemetrymgr.ITelemetryManager, error) {
+			configMgr, err := container.GetManager[configmgr.IConfigManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return telemetrymgr.BuildWithConfigProvider(configMgr)
+		}),
 
-	// 6. 初始化锁管理器（依赖配置管理器）
-	lockMgr, err := lockmgr.BuildWithConfigProvider(configManager, loggerManager, telemetryMgr, cacheMgr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create lock manager: %w", err)
-	}
-	if err := container.RegisterManager[lockmgr.ILockManager](cntr, lockMgr); err != nil {
-		return nil, fmt.Errorf("failed to register lock manager: %w", err)
-	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: LockManager")
+		// 日志管理器（依赖配置管理器和遥测管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (loggermgr.ILoggerManager, error) {
+			configMgr, telemetryMgr, err := baseManagers(m)
+			if err != nil {
+				return nil, err
+			}
+			return loggermgr.BuildWithConfigProvider(configMgr, telemetryMgr)
+		}, ManagerType[telemetrymgr.ITelemetryManager]()),
 
-	// 7. 初始化限流管理器（依赖配置管理器）
-	limiterMgr, err := limitermgr.BuildWithConfigProvider(configManager, loggerManager, telemetryMgr, cacheMgr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create limiter manager: %w", err)
-	}
-	if err := container.RegisterManager[limitermgr.ILimiterManager](cntr, limiterMgr); err != nil {
-		return nil, fmt.Errorf("failed to register limiter manager: %w", err)
-	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: LimiterManager")
+		// 数据库管理器（依赖配置管理器、日志管理器、遥测管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (databasemgr.IDatabaseManager, error) {
+			configMgr, telemetryMgr, err := baseManagers(m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return databasemgr.BuildWithConfigProvider(configMgr, loggerMgr, telemetryMgr)
+		}, ManagerType[telemetrymgr.ITelemetryManager](), ManagerType[loggermgr.ILoggerManager]()).WithConfigSection("database"),
 
-	// 8. 初始化消息队列管理器（依赖配置管理器、日志管理器、遥测管理器）
-	mqMgr, err := mqmgr.BuildWithConfigProvider(configManager, loggerManager, telemetryMgr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create mq manager: %w", err)
-	}
-	if err := container.RegisterManager[mqmgr.IMQManager](cntr, mqMgr); err != nil {
-		return nil, fmt.Errorf("failed to register mq manager: %w", err)
-	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: MQManager")
+		// 缓存管理器（依赖配置管理器、日志管理器、遥测管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (cachemgr.ICacheManager, error) {
+			configMgr, telemetryMgr, err := baseManagers(m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return cachemgr.BuildWithConfigProvider(configMgr, loggerMgr, telemetryMgr)
+		}, ManagerType[telemetrymgr.ITelemetryManager](), ManagerType[loggermgr.ILoggerManager]()).WithConfigSection("cache"),
 
-	// 9. 初始化定时任务管理器（依赖配置管理器、日志管理器）
-	schedulerMgr, err := schedulermgr.BuildWithConfigProvider(configManager, loggerManager)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create scheduler manager: %w", err)
-	}
-	if err := container.RegisterManager[schedulermgr.ISchedulerManager](cntr, schedulerMgr); err != nil {
-		return nil, fmt.Errorf("failed to register scheduler manager: %w", err)
+		// 锁管理器（依赖配置管理器、日志管理器、遥测管理器、缓存管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (lockmgr.ILockManager, error) {
+			configMgr, telemetryMgr, err := baseManagers(m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			cacheMgr, err := container.GetManager[cachemgr.ICacheManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return lockmgr.BuildWithConfigProvider(configMgr, loggerMgr, telemetryMgr, cacheMgr)
+		}, ManagerType[telemetrymgr.ITelemetryManager](), ManagerType[loggermgr.ILoggerManager](),
+			ManagerType[cachemgr.ICacheManager]()).WithConfigSection("lock"),
+
+		// 限流管理器（依赖配置管理器、日志管理器、遥测管理器、缓存管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (limitermgr.ILimiterManager, error) {
+			configMgr, telemetryMgr, err := baseManagers(m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			cacheMgr, err := container.GetManager[cachemgr.ICacheManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return limitermgr.BuildWithConfigProvider(configMgr, loggerMgr, telemetryMgr, cacheMgr)
+		}, ManagerType[telemetrymgr.ITelemetryManager](), ManagerType[loggermgr.ILoggerManager](),
+			ManagerType[cachemgr.ICacheManager]()).WithConfigSection("limiter"),
+
+		// 消息队列管理器（依赖配置管理器、日志管理器、遥测管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (mqmgr.IMQManager, error) {
+			configMgr, telemetryMgr, err := baseManagers(m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return mqmgr.BuildWithConfigProvider(configMgr, loggerMgr, telemetryMgr)
+		}, ManagerType[telemetrymgr.ITelemetryManager](), ManagerType[loggermgr.ILoggerManager]()).WithConfigSection("mq"),
+
+		// 定时任务管理器（依赖配置管理器、日志管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (schedulermgr.ISchedulerManager, error) {
+			configMgr, err := container.GetManager[configmgr.IConfigManager](m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return schedulermgr.BuildWithConfigProvider(configMgr, loggerMgr)
+		}, ManagerType[loggermgr.ILoggerManager]()).WithConfigSection("scheduler"),
+
+		// 服务状态通知管理器（依赖配置管理器、日志管理器）
+		NewManagerFactory(func(m *container.ManagerContainer) (notificationmgr.INotificationManager, error) {
+			configMgr, err := container.GetManager[configmgr.IConfigManager](m)
+			if err != nil {
+				return nil, err
+			}
+			loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](m)
+			if err != nil {
+				return nil, err
+			}
+			return notificationmgr.BuildWithConfigProvider(configMgr, loggerMgr)
+		}, ManagerType[loggermgr.ILoggerManager]()).WithConfigSection("notification"),
 	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: SchedulerManager")
+}
 
-	// 10. 初始化服务状态通知管理器（依赖配置管理器、日志管理器）
-	notificationMgr, err := notificationmgr.BuildWithConfigProvider(configManager, loggerManager)
+// baseManagers 获取大多数内置管理器共同依赖的配置管理器和遥测管理器
+func baseManagers(m *container.ManagerContainer) (configmgr.IConfigManager, telemetrymgr.ITelemetryManager, error) {
+	configMgr, err := container.GetManager[configmgr.IConfigManager](m)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create notification manager: %w", err)
+		return nil, nil, err
 	}
-	if err := container.RegisterManager[notificationmgr.INotificationManager](cntr, notificationMgr); err != nil {
-		return nil, fmt.Errorf("failed to register notification manager: %w", err)
+	telemetryMgr, err := container.GetManager[telemetrymgr.ITelemetryManager](m)
+	if err != nil {
+		return nil, nil, err
 	}
-	logStartup(tempLogger, PhaseManagers, "Initialization complete: NotificationManager")
-
-	logPhaseEnd(tempLogger, PhaseManagers, "Managers initialization complete", logger.F("count", 10))
-
-	return cntr, nil
+	return configMgr, telemetryMgr, nil
 }
@@ -1,57 +1,311 @@
 package server
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
 	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 // StartupLogConfig 启动日志配置
 type StartupLogConfig struct {
-	Enabled bool `yaml:"enabled"` // 是否启用启动日志
-	Async   bool `yaml:"async"`   // 是否异步输出（默认 true）
-	Buffer  int  `yaml:"buffer"`  // 缓冲区大小（默认 100）
+	Enabled       bool          `yaml:"enabled"`        // 是否启用启动日志
+	Async         bool          `yaml:"async"`          // 是否异步输出（默认 true）
+	Buffer        int           `yaml:"buffer"`         // 缓冲区大小（默认 100）
+	SlowThreshold time.Duration `yaml:"slow_threshold"` // 慢组件阈值，单个组件启动耗时超过时输出警告（默认 1s，0 表示不检测）
 }
 
 // DefaultStartupLogConfig 返回默认的启动日志配置
 func DefaultStartupLogConfig() *StartupLogConfig {
 	return &StartupLogConfig{
-		Enabled: true,
-		Async:   true,
-		Buffer:  100,
+		Enabled:       true,
+		Async:         true,
+		Buffer:        100,
+		SlowThreshold: time.Second,
+	}
+}
+
+// Validate 验证启动日志配置
+func (c *StartupLogConfig) Validate() error {
+	if c.Async && c.Buffer <= 0 {
+		return fmt.Errorf("server.startup_log.buffer: must be greater than 0 when async is enabled")
+	}
+	if c.SlowThreshold < 0 {
+		return fmt.Errorf("server.startup_log.slow_threshold: cannot be negative")
 	}
+	return nil
 }
 
-// serverConfig 服务器配置
+// AdminConfig 管理端监听器配置
+// 启用后系统路由（/api/health、/api/ready）和管理端控制器（如 pprof、metrics）挂载到独立端口
+type AdminConfig struct {
+	Enabled bool   `yaml:"enabled"` // 是否启用管理端监听器，默认关闭
+	Host    string `yaml:"host"`    // 监听地址，默认 127.0.0.1
+	Port    int    `yaml:"port"`    // 监听端口，默认 9090
+}
+
+// DefaultAdminConfig 返回默认的管理端监听器配置
+func DefaultAdminConfig() *AdminConfig {
+	return &AdminConfig{
+		Enabled: false,
+		Host:    "127.0.0.1",
+		Port:    9090,
+	}
+}
+
+// Validate 验证管理端监听器配置
+func (c *AdminConfig) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("server.admin.host is required")
+	}
+	return validatePort("server.admin.port", c.Port)
+}
+
+// Address 返回管理端监听地址
+func (c *AdminConfig) Address() string {
+	return c.Host + ":" + strconv.Itoa(c.Port)
+}
+
+// serverConfig 服务器配置（对应配置文件中的 server 段）
 type serverConfig struct {
-	Host              string            // 监听地址，默认 0.0.0.0
-	Port              int               // 监听端口，默认 8080
-	Mode              string            // 运行模式：debug/release/test，默认 release
-	ReadTimeout       time.Duration     // 读取超时，默认 10s
-	WriteTimeout      time.Duration     // 写入超时，默认 10s
-	IdleTimeout       time.Duration     // 空闲超时，默认 60s
-	ShutdownTimeout   time.Duration     // 关闭超时，默认 30s
-	RedirectFixedPath bool              // 是否开启路径自动重定向（如 /favicon.ico/ → /favicon.ico），默认关闭
-	RemoveExtraSlash  bool              // 是否移除路径中多余斜杠，默认关闭
-	StartupLog        *StartupLogConfig // 启动日志配置
+	Host                 string             `yaml:"host"`                   // 监听地址，默认 0.0.0.0
+	Port                 int                `yaml:"port"`                   // 监听端口，默认 8080
+	Mode                 string             `yaml:"mode"`                   // 运行模式：debug/release/test，默认 release
+	ReadTimeout          time.Duration      `yaml:"read_timeout"`           // 读取超时，默认 10s
+	WriteTimeout         time.Duration      `yaml:"write_timeout"`          // 写入超时，默认 10s
+	IdleTimeout          time.Duration      `yaml:"idle_timeout"`           // 空闲超时，默认 60s
+	ShutdownTimeout      time.Duration      `yaml:"shutdown_timeout"`       // 关闭超时，默认 30s
+	ReadHeaderTimeout    time.Duration      `yaml:"read_header_timeout"`    // 读取请求头超时，默认 0（使用 ReadTimeout）
+	RequestTimeout       time.Duration      `yaml:"request_timeout"`        // 控制器处理请求的默认超时，默认 0（不限制），可由控制器覆盖
+	MaxBodyBytes         int64              `yaml:"max_body_bytes"`         // 控制器路由的默认请求体最大字节数，默认 0（不限制），可由控制器覆盖
+	MaxHeaderBytes       int                `yaml:"max_header_bytes"`       // 请求头最大字节数，默认 1MB
+	H2C                  bool               `yaml:"h2c"`                    // 是否启用明文 HTTP/2（h2c），默认关闭
+	MaxConcurrentStreams int                `yaml:"max_concurrent_streams"` // HTTP/2 单连接最大并发流数，默认 0（使用 Go 默认值 250）
+	GracefulRestart      bool               `yaml:"graceful_restart"`       // 是否启用 SIGHUP/SIGUSR2 触发的平滑重启，默认关闭
+	RedirectFixedPath    bool               `yaml:"redirect_fixed_path"`    // 是否开启路径自动重定向（如 /favicon.ico/ → /favicon.ico），默认关闭
+	RemoveExtraSlash     bool               `yaml:"remove_extra_slash"`     // 是否移除路径中多余斜杠，默认关闭
+	LazyManagers         bool               `yaml:"lazy_managers"`          // 内置管理器是否默认延迟构建（仅在被注入时构建），默认关闭
+	StartupLog           *StartupLogConfig  `yaml:"startup_log"`            // 启动日志配置
+	TLS                  *TLSConfig         `yaml:"tls"`                    // HTTPS/mTLS 配置，默认关闭
+	Admin                *AdminConfig       `yaml:"admin"`                  // 管理端监听器配置，默认关闭
+	UnixSocket           *UnixSocketConfig  `yaml:"unix_socket"`            // Unix 域套接字监听配置，配置 path 后替代 host:port
+	Readiness            *ReadinessConfig   `yaml:"readiness"`              // 就绪检查配置
+	Shutdown             *ShutdownConfig    `yaml:"shutdown"`               // 关闭阶段配置
+	Maintenance          *MaintenanceConfig `yaml:"maintenance"`            // 维护模式配置
+
+	deprecatedKeys []string // 配置中出现的已废弃键（已忽略），由 Engine 输出废弃警告
+}
+
+// legacyServerKeys 已废弃的 server 配置键及说明，出现时忽略并警告而不是作为未知键报错，
+// 兼容旧版本脚手架生成的配置文件
+var legacyServerKeys = map[string]string{
+	"enable_recovery": "panic recovery is always enabled",
 }
 
 // defaultServerConfig 返回默认的服务器配置
 func defaultServerConfig() *serverConfig {
 	return &serverConfig{
-		Host:              "0.0.0.0",
-		Port:              8080,
-		Mode:              "release",
-		ReadTimeout:       10 * time.Second,
-		WriteTimeout:      10 * time.Second,
-		IdleTimeout:       60 * time.Second,
-		ShutdownTimeout:   30 * time.Second,
-		RedirectFixedPath: false,
-		RemoveExtraSlash:  false,
-		StartupLog:        DefaultStartupLogConfig(),
+		Host:                 "0.0.0.0",
+		Port:                 8080,
+		Mode:                 "release",
+		ReadTimeout:          10 * time.Second,
+		WriteTimeout:         10 * time.Second,
+		IdleTimeout:          60 * time.Second,
+		ShutdownTimeout:      30 * time.Second,
+		ReadHeaderTimeout:    0,
+		RequestTimeout:       0,
+		MaxBodyBytes:         0,
+		MaxHeaderBytes:       http.DefaultMaxHeaderBytes,
+		H2C:                  false,
+		MaxConcurrentStreams: 0,
+		GracefulRestart:      false,
+		RedirectFixedPath:    false,
+		RemoveExtraSlash:     false,
+		LazyManagers:         false,
+		StartupLog:           DefaultStartupLogConfig(),
+		TLS:                  DefaultTLSConfig(),
+		Admin:                DefaultAdminConfig(),
+		UnixSocket:           DefaultUnixSocketConfig(),
+		Readiness:            DefaultReadinessConfig(),
+		Shutdown:             DefaultShutdownConfig(),
+		Maintenance:          DefaultMaintenanceConfig(),
+	}
+}
+
+// decode 将配置文件中的 server 段解码到当前配置并校验
+// 仅覆盖出现的键；未知键、类型错误、非法取值均返回错误，启动直接失败而不是回退到默认值；
+// legacyServerKeys 中的已废弃键被忽略并记录到 deprecatedKeys
+func (c *serverConfig) decode(raw map[string]any) error {
+	c.fillDefaults()
+	c.deprecatedKeys = nil
+	filtered := make(map[string]any, len(raw))
+	for key, value := range raw {
+		if _, legacy := legacyServerKeys[key]; legacy {
+			c.deprecatedKeys = append(c.deprecatedKeys, key)
+			continue
+		}
+		filtered[key] = value
+	}
+	sort.Strings(c.deprecatedKeys)
+	if err := decodeConfigMap("server", filtered, c); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// fillDefaults 为未设置的子配置填充默认值
+func (c *serverConfig) fillDefaults() {
+	if c.StartupLog == nil {
+		c.StartupLog = DefaultStartupLogConfig()
+	}
+	if c.TLS == nil {
+		c.TLS = DefaultTLSConfig()
+	}
+	if c.Admin == nil {
+		c.Admin = DefaultAdminConfig()
+	}
+	if c.UnixSocket == nil {
+		c.UnixSocket = DefaultUnixSocketConfig()
+	}
+	if c.Readiness == nil {
+		c.Readiness = DefaultReadinessConfig()
+	}
+	if c.Shutdown == nil {
+		c.Shutdown = DefaultShutdownConfig()
+	}
+	if c.Maintenance == nil {
+		c.Maintenance = DefaultMaintenanceConfig()
+	}
+}
+
+// Validate 验证服务器配置，返回所有错误
+func (c *serverConfig) Validate() error {
+	var errs []error
+
+	if c.Host == "" && !c.UnixSocket.Enabled() {
+		errs = append(errs, fmt.Errorf("server.host is required"))
+	}
+	if err := validatePort("server.port", c.Port); err != nil {
+		errs = append(errs, err)
+	}
+	switch c.Mode {
+	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+	default:
+		errs = append(errs, fmt.Errorf("server.mode: unsupported value %q (must be debug, release or test)", c.Mode))
+	}
+
+	durations := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"read_timeout", c.ReadTimeout},
+		{"write_timeout", c.WriteTimeout},
+		{"idle_timeout", c.IdleTimeout},
+		{"shutdown_timeout", c.ShutdownTimeout},
+		{"read_header_timeout", c.ReadHeaderTimeout},
+		{"request_timeout", c.RequestTimeout},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("server.%s: cannot be negative", d.key))
+		}
+	}
+	if c.ShutdownTimeout == 0 {
+		errs = append(errs, fmt.Errorf("server.shutdown_timeout: must be greater than 0"))
+	}
+	if c.MaxHeaderBytes < 0 {
+		errs = append(errs, fmt.Errorf("server.max_header_bytes: cannot be negative"))
+	}
+	if c.MaxBodyBytes < 0 {
+		errs = append(errs, fmt.Errorf("server.max_body_bytes: cannot be negative"))
+	}
+	if c.MaxConcurrentStreams < 0 {
+		errs = append(errs, fmt.Errorf("server.max_concurrent_streams: cannot be negative"))
+	}
+
+	if c.StartupLog != nil {
+		if err := c.StartupLog.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.TLS != nil {
+		if err := c.TLS.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.UnixSocket != nil {
+		if err := c.UnixSocket.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.Readiness != nil {
+		if err := c.Readiness.Validate(); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	if c.Shutdown != nil {
+		if err := c.Shutdown.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.Maintenance != nil {
+		if err := c.Maintenance.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.Admin != nil && c.Admin.Enabled {
+		if err := c.Admin.Validate(); err != nil {
+			errs = append(errs, err)
+		} else if !c.UnixSocket.Enabled() && c.Admin.Port == c.Port {
+			errs = append(errs, fmt.Errorf("server.admin.port: must differ from server.port (%d)", c.Port))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validatePort 验证端口范围
+func validatePort(key string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("%s: %d out of range (1-65535)", key, port)
+	}
+	return nil
+}
+
+// Network 返回服务器监听的网络类型：tcp 或 unix
+func (c *serverConfig) Network() string {
+	if c.UnixSocket.Enabled() {
+		return "unix"
+	}
+	return "tcp"
 }
 
 // Address 返回服务器监听地址
+// 启用 Unix 域套接字时返回套接字文件路径
 func (c *serverConfig) Address() string {
+	if c.UnixSocket.Enabled() {
+		return c.UnixSocket.Path
+	}
 	return c.Host + ":" + strconv.Itoa(c.Port)
 }
+
+// Protocols 返回 HTTP 服务器启用的协议
+// 始终启用 HTTP/1.1；启用 TLS 时通过 ALPN 协商 HTTP/2；启用 h2c 时接受明文 HTTP/2（需部署在可信网络内）
+func (c *serverConfig) Protocols(tlsEnabled bool) *http.Protocols {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(tlsEnabled)
+	protocols.SetUnencryptedHTTP2(c.H2C)
+	return protocols
+}
+
+// HTTP2 返回 HTTP/2 配置
+func (c *serverConfig) HTTP2() *http.HTTP2Config {
+	return &http.HTTP2Config{
+		MaxConcurrentStreams: c.MaxConcurrentStreams,
+	}
+}
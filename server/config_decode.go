@@ -0,0 +1,247 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	fileModeType = reflect.TypeOf(os.FileMode(0))
+)
+
+// decodeConfigMap 将配置 map 解码到由 yaml 标签描述的结构体
+// - target 必须为结构体指针，仅覆盖 map 中出现的字段，未出现的字段保持原值（默认值）
+// - 未知键、类型不匹配、非法时长均视为错误，所有错误汇总后一次性返回；字符串字段接受数字标量
+// - 错误信息以 prefix 开头的完整键路径定位，如 "server.tls.min_version"
+func decodeConfigMap(prefix string, raw map[string]any, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a non-nil struct pointer, got %T", target)
+	}
+
+	var errs []error
+	decodeStruct(prefix, raw, rv.Elem(), &errs)
+	return errors.Join(errs...)
+}
+
+// decodeStruct 按 yaml 标签解码结构体字段
+func decodeStruct(path string, raw map[string]any, out reflect.Value, errs *[]error) {
+	fields := make(map[string]int)
+	for i := 0; i < out.NumField(); i++ {
+		field := out.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		fields[name] = i
+	}
+
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := joinKeyPath(path, key)
+		index, ok := fields[key]
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s: unknown key", keyPath))
+			continue
+		}
+		decodeValue(keyPath, raw[key], out.Field(index), errs)
+	}
+}
+
+// decodeValue 解码单个值
+func decodeValue(path string, value any, out reflect.Value, errs *[]error) {
+	// YAML 中的空值（如 "key:"）保留默认值
+	if value == nil {
+		return
+	}
+
+	fail := func(expected string) {
+		*errs = append(*errs, fmt.Errorf("%s: expected %s, got %s", path, expected, describeValue(value)))
+	}
+
+	switch out.Type() {
+	case durationType:
+		s, ok := value.(string)
+		if !ok {
+			fail(`duration string (e.g. "10s")`)
+			return
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: invalid duration %q", path, s))
+			return
+		}
+		out.SetInt(int64(d))
+		return
+	case fileModeType:
+		switch v := value.(type) {
+		case string:
+			mode, err := strconv.ParseUint(strings.TrimSpace(v), 8, 32)
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%s: invalid octal file mode %q", path, v))
+				return
+			}
+			out.SetUint(mode)
+		default:
+			n, ok := toInt64(value)
+			if !ok || n < 0 {
+				fail(`octal file mode string (e.g. "0660")`)
+				return
+			}
+			out.SetUint(uint64(n))
+		}
+		return
+	}
+
+	switch out.Kind() {
+	case reflect.Pointer:
+		if out.IsNil() {
+			out.Set(reflect.New(out.Type().Elem()))
+		}
+		decodeValue(path, value, out.Elem(), errs)
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			fail("map")
+			return
+		}
+		decodeStruct(path, m, out, errs)
+	case reflect.String:
+		s, ok := scalarString(value)
+		if !ok {
+			fail("string")
+			return
+		}
+		out.SetString(s)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			fail("bool")
+			return
+		}
+		out.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt64(value)
+		if !ok || out.OverflowInt(n) {
+			fail("integer")
+			return
+		}
+		out.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := toInt64(value)
+		if !ok || n < 0 || out.OverflowUint(uint64(n)) {
+			fail("non-negative integer")
+			return
+		}
+		out.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		switch v := value.(type) {
+		case float64:
+			out.SetFloat(v)
+		default:
+			n, ok := toInt64(value)
+			if !ok {
+				fail("number")
+				return
+			}
+			out.SetFloat(float64(n))
+		}
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if !ok {
+			fail("list")
+			return
+		}
+		slice := reflect.MakeSlice(out.Type(), len(items), len(items))
+		for i, item := range items {
+			decodeValue(fmt.Sprintf("%s[%d]", path, i), item, slice.Index(i), errs)
+		}
+		out.Set(slice)
+	default:
+		*errs = append(*errs, fmt.Errorf("%s: unsupported config field type %s", path, out.Type()))
+	}
+}
+
+// toInt64 将整数值转换为 int64（JSON 解析得到的 float64 需为整数）
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case float64:
+		if v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// scalarString 将字符串或数字标量转换为字符串，兼容 YAML 中未加引号的数字（如 min_version: 1.2）
+// 小数保留小数点（1.0 转换为 "1.0"）
+func scalarString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.ContainsAny(s, ".eEN") {
+			s += ".0"
+		}
+		return s, true
+	default:
+		return "", false
+	}
+}
+
+// describeValue 描述配置值的类型，用于错误信息
+func describeValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("bool %v", v)
+	case int, int64, uint64, float64:
+		return fmt.Sprintf("number %v", v)
+	case map[string]any:
+		return "map"
+	case []any:
+		return "list"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// joinKeyPath 拼接配置键路径
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"net"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -38,6 +41,14 @@ func TestDefaultServerConfig(t *testing.T) {
 		if config.ShutdownTimeout != 30*time.Second {
 			t.Errorf("期望 ShutdownTimeout = 30s, 实际 = %v", config.ShutdownTimeout)
 		}
+
+		if config.MaxHeaderBytes != http.DefaultMaxHeaderBytes {
+			t.Errorf("期望 MaxHeaderBytes = %d, 实际 = %d", http.DefaultMaxHeaderBytes, config.MaxHeaderBytes)
+		}
+
+		if config.H2C {
+			t.Errorf("期望 H2C = false")
+		}
 	})
 }
 
@@ -228,3 +239,183 @@ func TestServerConfigTimeoutValues(t *testing.T) {
 		})
 	}
 }
+
+// TestServerConfigProtocols 测试协议配置
+func TestServerConfigProtocols(t *testing.T) {
+	t.Run("默认配置_仅HTTP1", func(t *testing.T) {
+		protocols := defaultServerConfig().Protocols(false)
+		if !protocols.HTTP1() || protocols.HTTP2() || protocols.UnencryptedHTTP2() {
+			t.Errorf("期望仅启用 HTTP/1, 实际 %v", protocols)
+		}
+	})
+
+	t.Run("启用TLS_协商HTTP2", func(t *testing.T) {
+		protocols := defaultServerConfig().Protocols(true)
+		if !protocols.HTTP1() || !protocols.HTTP2() || protocols.UnencryptedHTTP2() {
+			t.Errorf("期望启用 HTTP/1 和 HTTP/2, 实际 %v", protocols)
+		}
+	})
+
+	t.Run("启用h2c_接受明文HTTP2", func(t *testing.T) {
+		config := defaultServerConfig()
+		config.H2C = true
+		config.MaxConcurrentStreams = 100
+
+		protocols := config.Protocols(false)
+		if !protocols.HTTP1() || !protocols.UnencryptedHTTP2() {
+			t.Errorf("期望启用 HTTP/1 和 h2c, 实际 %v", protocols)
+		}
+		if config.HTTP2().MaxConcurrentStreams != 100 {
+			t.Errorf("期望 MaxConcurrentStreams = 100, 实际 = %d", config.HTTP2().MaxConcurrentStreams)
+		}
+	})
+}
+
+// TestH2CServer 测试 h2c 服务端可处理明文 HTTP/2 请求
+func TestH2CServer(t *testing.T) {
+	config := defaultServerConfig()
+	config.H2C = true
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Proto", r.Proto)
+		}),
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
+		Protocols:         config.Protocols(false),
+		HTTP2:             config.HTTP2(),
+	}
+	go server.Serve(ln)
+	defer server.Close()
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+
+	resp, err := client.Get("http://" + ln.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.ProtoMajor != 2 || resp.Header.Get("X-Proto") != "HTTP/2.0" {
+		t.Errorf("期望使用 HTTP/2, 实际 %s", resp.Proto)
+	}
+}
+
+// TestServerConfigDecode 测试 server 配置段解码与校验
+func TestServerConfigDecode(t *testing.T) {
+	t.Run("完整配置_正确解码", func(t *testing.T) {
+		config := defaultServerConfig()
+		err := config.decode(map[string]any{
+			"host":             "127.0.0.1",
+			"port":             float64(9000), // JSON 驱动解析出的数字
+			"mode":             "test",
+			"read_timeout":     "5s",
+			"shutdown_timeout": "15s",
+			"h2c":              true,
+			"startup_log": map[string]any{
+				"enabled": false,
+				"buffer":  50,
+			},
+			"admin": map[string]any{
+				"enabled": true,
+				"port":    9091,
+			},
+		})
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+
+		if config.Host != "127.0.0.1" || config.Port != 9000 || config.Mode != "test" {
+			t.Errorf("基础配置解码不正确: %+v", config)
+		}
+		if config.ReadTimeout != 5*time.Second || config.ShutdownTimeout != 15*time.Second {
+			t.Errorf("超时配置解码不正确: read=%v shutdown=%v", config.ReadTimeout, config.ShutdownTimeout)
+		}
+		if config.WriteTimeout != 10*time.Second {
+			t.Errorf("未配置的键期望保留默认值, 实际 WriteTimeout = %v", config.WriteTimeout)
+		}
+		if config.StartupLog.Enabled || !config.StartupLog.Async || config.StartupLog.Buffer != 50 {
+			t.Errorf("startup_log 解码不正确: %+v", config.StartupLog)
+		}
+		if !config.Admin.Enabled || config.Admin.Host != "127.0.0.1" || config.Admin.Port != 9091 {
+			t.Errorf("admin 解码不正确: %+v", config.Admin)
+		}
+	})
+
+	t.Run("已废弃键_忽略并记录", func(t *testing.T) {
+		config := defaultServerConfig()
+		if err := config.decode(map[string]any{"enable_recovery": true, "port": 9000}); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if config.Port != 9000 {
+			t.Errorf("期望 Port = 9000, 实际 = %d", config.Port)
+		}
+		if len(config.deprecatedKeys) != 1 || config.deprecatedKeys[0] != "enable_recovery" {
+			t.Errorf("期望记录已废弃键 enable_recovery, 实际 %v", config.deprecatedKeys)
+		}
+	})
+
+	t.Run("未配置server段_使用默认值", func(t *testing.T) {
+		config := defaultServerConfig()
+		if err := config.decode(nil); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if config.Port != 8080 {
+			t.Errorf("期望 Port = 8080, 实际 = %d", config.Port)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		raw      map[string]any
+		expected string
+	}{
+		{name: "端口为字符串", raw: map[string]any{"port": "8080"}, expected: `server.port: expected integer, got string "8080"`},
+		{name: "端口越界", raw: map[string]any{"port": 70000}, expected: "server.port: 70000 out of range"},
+		{name: "端口为小数", raw: map[string]any{"port": 80.5}, expected: "server.port: expected integer"},
+		{name: "未知键", raw: map[string]any{"enable_recover": true}, expected: "server.enable_recover: unknown key"},
+		{name: "非法时长", raw: map[string]any{"read_timeout": "10"}, expected: `server.read_timeout: invalid duration "10"`},
+		{name: "时长为数字", raw: map[string]any{"idle_timeout": 60}, expected: "server.idle_timeout: expected duration string"},
+		{name: "非法模式", raw: map[string]any{"mode": "prod"}, expected: `server.mode: unsupported value "prod"`},
+		{name: "嵌套未知键", raw: map[string]any{"startup_log": map[string]any{"level": "info"}}, expected: "server.startup_log.level: unknown key"},
+		{name: "嵌套类型错误", raw: map[string]any{"startup_log": map[string]any{"async": "yes"}}, expected: `server.startup_log.async: expected bool, got string "yes"`},
+		{name: "慢组件阈值为负数", raw: map[string]any{"startup_log": map[string]any{"slow_threshold": "-1s"}}, expected: "server.startup_log.slow_threshold: cannot be negative"},
+		{name: "子配置不是map", raw: map[string]any{"tls": true}, expected: "server.tls: expected map, got bool true"},
+		{name: "管理端端口冲突", raw: map[string]any{"admin": map[string]any{"enabled": true, "port": 8080}}, expected: "server.admin.port: must differ from server.port"},
+	}
+
+	for _, tt := range tests {
+		t.Run("非法配置_"+tt.name, func(t *testing.T) {
+			err := defaultServerConfig().decode(tt.raw)
+			if err == nil {
+				t.Fatalf("期望返回错误")
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("期望错误包含 %q, 实际 %q", tt.expected, err.Error())
+			}
+		})
+	}
+
+	t.Run("多个错误_一次性返回", func(t *testing.T) {
+		err := defaultServerConfig().decode(map[string]any{
+			"port":          "8080",
+			"write_timeout": "abc",
+			"unknown":       1,
+		})
+		if err == nil {
+			t.Fatalf("期望返回错误")
+		}
+		for _, key := range []string{"server.port", "server.write_timeout", "server.unknown"} {
+			if !strings.Contains(err.Error(), key) {
+				t.Errorf("期望错误包含 %s, 实际 %q", key, err.Error())
+			}
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+// dependencyGraphContentTypes 各导出格式的响应类型
+var dependencyGraphContentTypes = map[string]string{
+	container.GraphFormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	container.GraphFormatMermaid: "text/plain; charset=utf-8",
+	container.GraphFormatJSON:    "application/json; charset=utf-8",
+}
+
+// DependencyGraph 返回应用的跨层依赖图（Manager 到 Scheduler 的所有组件及其注入依赖）
+// 管理器在 Initialize 中构建，Initialize 之前的依赖图不包含 Manager 层
+func (e *Engine) DependencyGraph() *container.DependencyGraph {
+	return container.BuildDependencyGraph(container.GraphContainers{
+		Manager:    e.Manager,
+		Entity:     e.Entity,
+		Repository: e.Repository,
+		Service:    e.Service,
+		Controller: e.Controller,
+		Middleware: e.Middleware,
+		Listener:   e.Listener,
+		Scheduler:  e.Scheduler,
+	})
+}
+
+// handleDependencyGraph 依赖图端点：按 format 参数（json、dot、mermaid，默认 json）导出依赖图
+func (e *Engine) handleDependencyGraph(c *gin.Context) {
+	format := c.DefaultQuery("format", container.GraphFormatJSON)
+	contentType, ok := dependencyGraphContentTypes[format]
+	if !ok {
+		_ = c.Error(common.NewBadRequest("unsupported format " + format + ", expected json, dot or mermaid"))
+		return
+	}
+	body, err := e.DependencyGraph().Render(format)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Data(http.StatusOK, contentType, []byte(body))
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+type iTestGraphRepository interface{ common.IBaseRepository }
+type iTestGraphService interface{ common.IBaseService }
+
+// testGraphRepository 依赖图测试用的仓储
+type testGraphRepository struct{}
+
+func (r *testGraphRepository) RepositoryName() string { return "GraphRepository" }
+func (r *testGraphRepository) OnStart() error         { return nil }
+func (r *testGraphRepository) OnStop() error          { return nil }
+
+// testGraphService 依赖仓储的测试服务
+type testGraphService struct {
+	Repo iTestGraphRepository `inject:""`
+}
+
+func (s *testGraphService) ServiceName() string { return "GraphService" }
+func (s *testGraphService) OnStart() error      { return nil }
+func (s *testGraphService) OnStop() error       { return nil }
+
+// TestDependencyGraphEndpoint 测试依赖图导出端点
+func TestDependencyGraphEndpoint(t *testing.T) {
+	newEngine := func(t *testing.T) *Engine {
+		engine := newRouteTestEngine(t)
+		engine.serverConfig.Mode = "debug"
+		engine.installErrorHandling(engine.ginEngine)
+		_ = container.RegisterRepository[iTestGraphRepository](engine.Repository, &testGraphRepository{})
+		_ = container.RegisterService[iTestGraphService](engine.Service, &testGraphService{})
+		engine.registerSystemRoutes()
+		return engine
+	}
+	get := func(engine *Engine, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/dependencies"+query, nil))
+		return w
+	}
+
+	t.Run("默认JSON", func(t *testing.T) {
+		w := get(newEngine(t), "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("期望 200, 实际 %d", w.Code)
+		}
+		var graph container.DependencyGraph
+		if err := json.Unmarshal(w.Body.Bytes(), &graph); err != nil {
+			t.Fatalf("解析响应失败: %v", err)
+		}
+		if len(graph.Nodes) != 2 || len(graph.Edges) != 1 || graph.Edges[0].Field != "Repo" {
+			t.Errorf("依赖图内容不正确: %+v", graph)
+		}
+	})
+
+	t.Run("DOT和Mermaid", func(t *testing.T) {
+		tests := []struct {
+			format      string
+			contentType string
+			want        string
+		}{
+			{"dot", "text/vnd.graphviz", `"Service:server.iTestGraphService" -> "Repository:server.iTestGraphRepository" [label="Repo"];`},
+			{"mermaid", "text/plain", `n1 -->|"Repo"| n0`},
+		}
+		engine := newEngine(t)
+		for _, tt := range tests {
+			w := get(engine, "?format="+tt.format)
+			if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), tt.contentType) {
+				t.Fatalf("%s: 期望 200 %s, 实际 %d %s", tt.format, tt.contentType, w.Code, w.Header().Get("Content-Type"))
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("%s: 期望包含 %q, 实际:\n%s", tt.format, tt.want, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("不支持的格式_返回400", func(t *testing.T) {
+		assertAPIError(t, get(newEngine(t), "?format=svg"), http.StatusBadRequest, common.CodeBadRequest)
+	})
+
+	t.Run("release模式_不注册", func(t *testing.T) {
+		engine := newRouteTestEngine(t)
+		engine.registerSystemRoutes()
+		if w := get(engine, ""); w.Code != http.StatusNotFound {
+			t.Errorf("期望 404, 实际 %d", w.Code)
+		}
+	})
+}
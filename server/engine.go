@@ -2,13 +2,16 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -18,7 +21,9 @@ import (
 	"github.com/lite-lake/litecore-go/container"
 	"github.com/lite-lake/litecore-go/logger"
 	"github.com/lite-lake/litecore-go/manager/configmgr"
+	"github.com/lite-lake/litecore-go/manager/databasemgr"
 	"github.com/lite-lake/litecore-go/manager/loggermgr"
+	"github.com/lite-lake/litecore-go/manager/mqmgr"
 	"github.com/lite-lake/litecore-go/manager/notificationmgr"
 	"github.com/lite-lake/litecore-go/manager/schedulermgr"
 )
@@ -27,6 +32,16 @@ import (
 type Engine struct {
 	// 内置配置（在 Initialize 时用于初始化内置组件）
 	builtinConfig *BuiltinConfig
+	configManager configmgr.IConfigManager // 通过 WithConfigMap/WithConfigManager 设置，优先于 builtinConfig
+
+	// 通过 WithManagerFactory 注册的自定义管理器工厂
+	managerFactories []*ManagerFactory
+
+	// 通过 WithManager 设置的管理器替换项，内置管理器初始化后生效
+	managerOverrides []managerOverride
+
+	// 通过 WithGinOptions 设置的 Gin 引擎选项
+	ginOptions []gin.OptionFunc
 
 	// 容器
 	Manager    *container.ManagerContainer // 内置组件（在 Initialize 时初始化）
@@ -41,6 +56,27 @@ type Engine struct {
 	// HTTP 服务器
 	httpServer *http.Server
 	ginEngine  *gin.Engine
+	listeners  []namedListener // 当前进程持有的监听器（平滑重启时传递给新进程）
+	handedOff  bool            // 监听器是否已通过平滑重启移交给新进程
+	tlsCerts   *certReloader   // TLS 证书热加载器（未启用 TLS 时为 nil）
+
+	// 管理端 HTTP 服务器（启用 server.admin 时创建）
+	adminServer *http.Server
+	adminEngine *gin.Engine
+
+	// 全局中间件名称（按执行顺序）与限定作用范围的中间件（在注册路由时按分组/路径挂载）
+	globalMiddlewares []string
+	scopedMiddlewares []*scopedMiddleware
+
+	// 已注册路由表（Initialize 注册路由时填充）
+	routeRegistry *routeRegistry
+	systemPaths   map[string]bool // 挂载在公共端口的系统路由路径，维护模式下不拦截
+
+	// 维护模式（Initialize 时按 server.maintenance 创建）
+	maintenance *maintenanceMode
+
+	// 已注册到 SchedulerManager 的定时器（按名称，维护模式下可暂停的包装）
+	registeredSchedulers map[string]common.IBaseScheduler
 
 	// 配置
 	serverConfig    *serverConfig
@@ -51,16 +87,25 @@ type Engine struct {
 	ctx     context.Context
 	cancel  context.CancelFunc
 	started bool
-	ready   bool
+	ready   atomic.Bool // 是否就绪（/api/ready 返回 ok/degraded），不受 mu 保护，避免启动门控期间阻塞探针
 	mu      sync.RWMutex
 
+	// 关闭阶段
+	stopConsuming  context.CancelFunc // 停止监听器消费（取消订阅使用的 ctx）
+	listenerTasks  *inflightTracker   // 进行中的监听器消息处理
+	shutdownReport ShutdownReport     // 最近一次 Stop 的关闭报告
+	shutdownMu     sync.Mutex         // 保护 shutdownReport，Stop 期间也可读取
+
+	// 就绪检查
+	readiness    *readinessChecker
+	healthChecks []HealthCheck // 通过 AddHealthCheck 注册的自定义检查项
+
 	// 启动日志配置
 	startupLogConfig *StartupLogConfig
 
 	// 启动时间统计
-	startupStartTime time.Time
-	phaseDurations   map[StartupPhase]time.Duration
-	phaseStartTimes  map[StartupPhase]time.Time
+	startup         *startupRecorder // 启动耗时报告，Initialize 时重建
+	phaseStartTimes map[StartupPhase]time.Time
 
 	// 日志器（统一使用 logger.ILogger）
 	internalLogger logger.ILogger
@@ -71,6 +116,9 @@ type Engine struct {
 	asyncLogger *AsyncStartupLogger
 }
 
+// NewEngine 创建服务引擎
+// opts 为可选配置（WithConfigMap、WithConfigManager、WithManager、WithManagerFactory、WithGinOptions），
+// 未设置配置管理器时在 Initialize 中按 builtinConfig 读取配置文件
 func NewEngine(
 	builtinConfig *BuiltinConfig,
 	entity *container.EntityContainer,
@@ -80,11 +128,12 @@ func NewEngine(
 	middleware *container.MiddlewareContainer,
 	listener *container.ListenerContainer,
 	scheduler *container.SchedulerContainer,
+	opts ...EngineOption,
 ) *Engine {
 	ctx, cancel := context.WithCancel(context.Background())
 	defaultConfig := defaultServerConfig()
 
-	return &Engine{
+	e := &Engine{
 		Entity:           entity,
 		Repository:       repository,
 		Service:          service,
@@ -96,11 +145,15 @@ func NewEngine(
 		shutdownTimeout:  defaultConfig.ShutdownTimeout,
 		ctx:              ctx,
 		cancel:           cancel,
+		listenerTasks:    newInflightTracker(),
 		builtinConfig:    builtinConfig,
 		startupLogConfig: defaultConfig.StartupLog,
-		phaseDurations:   make(map[StartupPhase]time.Duration),
 		phaseStartTimes:  make(map[StartupPhase]time.Time),
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 func (e *Engine) logger() logger.ILogger {
@@ -144,18 +197,43 @@ func (e *Engine) Initialize() error {
 	defer e.mu.Unlock()
 
 	// 初始化启动时间统计
-	e.startupStartTime = time.Now()
+	e.startup = newStartupRecorder()
 
 	// 初始化前使用默认日志器
 	e.setLogger(logger.NewDefaultLogger("Engine"))
 	e.isStartup = true
 
-	// 1. 初始化内置组件
-	builtInManagerContainer, err := Initialize(e.builtinConfig)
+	// 1. 初始化内置组件（设置了配置管理器时不再读取配置文件）
+	configManager := e.configManager
+	var err error
+	if configManager == nil {
+		if e.builtinConfig == nil {
+			return fmt.Errorf("failed to initialize builtin components: builtin config is required when no config manager is set")
+		}
+		start := time.Now()
+		configManager, err = loadConfigManager(e.builtinConfig)
+		e.recordComponent(PhaseConfig, ComponentConfigLoad, e.builtinConfig.FilePath, time.Since(start))
+		if err != nil {
+			return fmt.Errorf("failed to initialize builtin components: %w", err)
+		}
+	}
+	required := e.requiredManagerTypes()
+	// 通知管理器仅为引擎发送事件所需，显式关闭通知时不视为被需要
+	if enabled, err := configBool(configManager, "notification.enabled", true); err == nil && !enabled {
+		delete(required, ManagerType[notificationmgr.INotificationManager]())
+	}
+	builtInManagerContainer, err := initializeManagers(configManager, e.managerFactories, func(ifaceType reflect.Type) bool {
+		return required[ifaceType]
+	}, func(name string, d time.Duration) {
+		e.recordComponent(PhaseManagers, ComponentManagerBuild, name, d)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize builtin components: %w", err)
 	}
 	e.Manager = builtInManagerContainer
+	if err := e.applyManagerOverrides(); err != nil {
+		return err
+	}
 
 	// 读取自动迁移配置
 	e.autoMigrateDB = false
@@ -169,81 +247,46 @@ func (e *Engine) Initialize() error {
 		}
 	}
 
-	// 从配置文件中读取 server 配置并覆盖默认值
+	// 从配置文件中解码 server 配置并覆盖默认值（配置错误时启动失败）
+	serverSection := map[string]any{}
 	if configMgr := e.Manager.GetByType(reflect.TypeOf((*configmgr.IConfigManager)(nil)).Elem()); configMgr != nil {
 		if mgr, ok := configMgr.(configmgr.IConfigManager); ok {
-			if host, err := mgr.Get("server.host"); err == nil {
-				if hostStr, ok := host.(string); ok && hostStr != "" {
-					e.serverConfig.Host = hostStr
-				}
-			}
-			if port, err := mgr.Get("server.port"); err == nil {
-				if portInt, ok := port.(int); ok && portInt > 0 {
-					e.serverConfig.Port = portInt
-				}
-			}
-			if mode, err := mgr.Get("server.mode"); err == nil {
-				if modeStr, ok := mode.(string); ok && modeStr != "" {
-					e.serverConfig.Mode = modeStr
-				}
-			}
-			if readTimeout, err := mgr.Get("server.read_timeout"); err == nil {
-				if timeoutStr, ok := readTimeout.(string); ok && timeoutStr != "" {
-					if duration, err := time.ParseDuration(timeoutStr); err == nil {
-						e.serverConfig.ReadTimeout = duration
-					}
-				}
-			}
-			if writeTimeout, err := mgr.Get("server.write_timeout"); err == nil {
-				if timeoutStr, ok := writeTimeout.(string); ok && timeoutStr != "" {
-					if duration, err := time.ParseDuration(timeoutStr); err == nil {
-						e.serverConfig.WriteTimeout = duration
-					}
-				}
-			}
-			if idleTimeout, err := mgr.Get("server.idle_timeout"); err == nil {
-				if timeoutStr, ok := idleTimeout.(string); ok && timeoutStr != "" {
-					if duration, err := time.ParseDuration(timeoutStr); err == nil {
-						e.serverConfig.IdleTimeout = duration
-					}
-				}
-			}
-			if shutdownTimeout, err := mgr.Get("server.shutdown_timeout"); err == nil {
-				if timeoutStr, ok := shutdownTimeout.(string); ok && timeoutStr != "" {
-					if duration, err := time.ParseDuration(timeoutStr); err == nil {
-						e.serverConfig.ShutdownTimeout = duration
-						e.shutdownTimeout = duration
-					}
-				}
-			}
-			if redirectFixedPath, err := mgr.Get("server.redirect_fixed_path"); err == nil {
-				if v, ok := redirectFixedPath.(bool); ok {
-					e.serverConfig.RedirectFixedPath = v
-				}
-			}
-			if removeExtraSlash, err := mgr.Get("server.remove_extra_slash"); err == nil {
-				if v, ok := removeExtraSlash.(bool); ok {
-					e.serverConfig.RemoveExtraSlash = v
-				}
-			}
-			if startupLog, err := mgr.Get("server.startup_log"); err == nil {
-				if startupLogMap, ok := startupLog.(map[string]interface{}); ok {
-					if e.serverConfig.StartupLog == nil {
-						e.serverConfig.StartupLog = DefaultStartupLogConfig()
-					}
-					if enabled, ok := startupLogMap["enabled"].(bool); ok {
-						e.serverConfig.StartupLog.Enabled = enabled
-					}
-					if async, ok := startupLogMap["async"].(bool); ok {
-						e.serverConfig.StartupLog.Async = async
-					}
-					if buffer, ok := startupLogMap["buffer"].(int); ok {
-						e.serverConfig.StartupLog.Buffer = buffer
-					}
+			if section, err := mgr.Get("server"); err == nil && section != nil {
+				sectionMap, ok := section.(map[string]any)
+				if !ok {
+					return fmt.Errorf("invalid server config: expected map, got %T", section)
 				}
+				serverSection = sectionMap
 			}
 		}
 	}
+	if err := e.serverConfig.decode(serverSection); err != nil {
+		return fmt.Errorf("invalid server config: %w", err)
+	}
+	for _, key := range e.serverConfig.deprecatedKeys {
+		e.getLogger().Warn("Deprecated server config key ignored",
+			logger.F("key", "server."+key),
+			logger.F("reason", legacyServerKeys[key]))
+	}
+	e.shutdownTimeout = e.serverConfig.ShutdownTimeout
+	e.startupLogConfig = e.serverConfig.StartupLog
+	e.startup.setThreshold(e.startupLogConfig.SlowThreshold)
+	e.maintenance = newMaintenanceMode(e.serverConfig.Maintenance)
+
+	// 创建就绪检查器：所有 Manager 的 Health 以及自定义检查项
+	e.readiness = newReadinessChecker(e.serverConfig.Readiness)
+	for _, mgr := range e.Manager.GetAll() {
+		if check, ok := managerHealthCheck(mgr); ok {
+			e.readiness.add(check)
+		}
+	}
+	healthCheckers := e.discoverHealthCheckers()
+	for name, checker := range healthCheckers {
+		e.readiness.add(HealthCheck{Name: name, Check: checker.Health})
+	}
+	for _, check := range e.healthChecks {
+		e.readiness.add(check)
+	}
 
 	// 切换到结构化日志
 	if loggerMgr, err := container.GetManager[loggermgr.ILoggerManager](e.Manager); err == nil {
@@ -259,6 +302,9 @@ func (e *Engine) Initialize() error {
 		fmt.Fprintf(os.Stderr, "Failed to get logger manager: %v, using default logger\n", err)
 	}
 
+	// 校验和依赖注入的问题汇总后一次性返回
+	var problems startupProblems
+
 	// 2. 验证 Scheduler 配置（在依赖注入之前）
 	if e.Scheduler != nil {
 		e.logPhaseStart(PhaseValidation, "Starting to validate Scheduler configuration")
@@ -269,7 +315,7 @@ func (e *Engine) Initialize() error {
 			schedulers := e.Scheduler.GetAll()
 			for _, scheduler := range schedulers {
 				if err := schedulerMgr.ValidateScheduler(scheduler); err != nil {
-					panic(fmt.Sprintf("scheduler %s crontab validation failed: %v", scheduler.SchedulerName(), err))
+					problems.add(fmt.Sprintf("scheduler %s crontab validation failed", scheduler.SchedulerName()), err)
 				}
 			}
 		}
@@ -278,51 +324,73 @@ func (e *Engine) Initialize() error {
 	}
 
 	// 3. 自动依赖注入
-	if err := e.autoInject(); err != nil {
-		return fmt.Errorf("auto inject failed: %w", err)
+	e.autoInject(&problems)
+	if err := problems.err(); err != nil {
+		return err
 	}
 
-	// 设置 Gin 模式
+	// 设置 Gin 模式：gin 模式为进程级全局状态，同一进程内多个 Engine 以最后初始化的配置为准
 	gin.SetMode(e.serverConfig.Mode)
 
 	// 创建 Gin 引擎
-	e.ginEngine = gin.New()
+	e.ginEngine = gin.New(e.ginOptions...)
 	e.ginEngine.RedirectFixedPath = e.serverConfig.RedirectFixedPath
 	e.ginEngine.RemoveExtraSlash = e.serverConfig.RemoveExtraSlash
 
+	// 统一错误处理：置于所有中间件之前，渲染处理链中的错误和 panic
+	e.installErrorHandling(e.ginEngine)
+
+	// 加载 TLS 证书（证书无效时启动失败），并在所有中间件之前写入客户端证书身份
+	if e.serverConfig.TLS != nil && e.serverConfig.TLS.Enabled {
+		tlsCerts, err := newCertReloader(e.serverConfig.TLS, e.serverConfig.Protocols(true))
+		if err != nil {
+			return fmt.Errorf("load tls config failed: %w", err)
+		}
+		e.tlsCerts = tlsCerts
+		e.ginEngine.Use(clientCertIdentityHandler)
+	}
+
+	// 维护模式：在业务中间件之前拦截请求
+	e.ginEngine.Use(e.maintenanceHandler())
+
+	// 创建管理端 Gin 引擎
+	if e.adminEnabled() {
+		e.initAdminEngine()
+	}
+
 	// 注册中间件
 	if err := e.registerMiddlewares(); err != nil {
 		return fmt.Errorf("register middlewares failed: %w", err)
 	}
 
-	// 添加 NoRoute 处理器
-	e.ginEngine.NoRoute(func(c *gin.Context) {
-		e.logger().Warn("Route not found", "path", c.Request.URL.Path, "method", c.Request.Method)
-		c.JSON(common.HTTPStatusNotFound, gin.H{
-			"error":  "route not found",
-			"path":   c.Request.URL.Path,
-			"method": c.Request.Method,
-		})
-	})
-
 	// 注册系统路由（/health、/ready）
 	e.registerSystemRoutes()
 
 	// 注册控制器路由
-	if err := e.registerControllers(); err != nil {
+	if err := e.timeComponent(PhaseRouter, ComponentRoutes, "controllers", e.registerControllers); err != nil {
 		return fmt.Errorf("register controllers failed: %w", err)
 	}
 
 	// 初始化需要 Gin 引擎的服务（如 HTML 模板服务）
 	e.initializeGinEngineServices()
 
+	// 为需要健康检查者的控制器（如 HealthController）传入自动发现的检查者
+	e.initializeHealthCheckerAware(healthCheckers)
+
 	// 创建 HTTP 服务器
 	e.httpServer = &http.Server{
-		Addr:         e.serverConfig.Address(),
-		Handler:      e.ginEngine,
-		ReadTimeout:  e.serverConfig.ReadTimeout,
-		WriteTimeout: e.serverConfig.WriteTimeout,
-		IdleTimeout:  e.serverConfig.IdleTimeout,
+		Addr:              e.serverConfig.Address(),
+		Handler:           e.ginEngine,
+		ReadTimeout:       e.serverConfig.ReadTimeout,
+		ReadHeaderTimeout: e.serverConfig.ReadHeaderTimeout,
+		WriteTimeout:      e.serverConfig.WriteTimeout,
+		IdleTimeout:       e.serverConfig.IdleTimeout,
+		MaxHeaderBytes:    e.serverConfig.MaxHeaderBytes,
+		Protocols:         e.serverConfig.Protocols(e.tlsCerts != nil),
+		HTTP2:             e.serverConfig.HTTP2(),
+	}
+	if e.tlsCerts != nil {
+		e.httpServer.TLSConfig = e.tlsCerts.TLSConfig()
 	}
 
 	// 发送服务启动中通知
@@ -333,86 +401,160 @@ func (e *Engine) Initialize() error {
 	return nil
 }
 
+// requiredManagerTypes 返回组件通过 inject 标签、构造函数参数需要的以及引擎自身使用的管理器接口类型
+// 用于决定延迟构建（lazy）的管理器是否需要构建
+func (e *Engine) requiredManagerTypes() map[reflect.Type]bool {
+	var instances []any
+	// 构造函数注册的组件在注入时才创建，使用构造函数的参数类型
+	var depTypes []reflect.Type
+	if e.Repository != nil {
+		depTypes = append(depTypes, e.Repository.ConstructorParamTypes()...)
+		for _, repo := range e.Repository.GetAll() {
+			instances = append(instances, repo)
+		}
+	}
+	if e.Service != nil {
+		depTypes = append(depTypes, e.Service.ConstructorParamTypes()...)
+		for _, svc := range e.Service.GetAll() {
+			instances = append(instances, svc)
+		}
+	}
+	if e.Controller != nil {
+		depTypes = append(depTypes, e.Controller.ConstructorParamTypes()...)
+		for _, ctrl := range e.Controller.GetAll() {
+			instances = append(instances, ctrl)
+		}
+	}
+	if e.Middleware != nil {
+		depTypes = append(depTypes, e.Middleware.ConstructorParamTypes()...)
+		for _, mw := range e.Middleware.GetAll() {
+			instances = append(instances, mw)
+		}
+	}
+	if e.Listener != nil {
+		depTypes = append(depTypes, e.Listener.ConstructorParamTypes()...)
+		for _, listener := range e.Listener.GetAll() {
+			instances = append(instances, listener)
+		}
+	}
+	if e.Scheduler != nil {
+		depTypes = append(depTypes, e.Scheduler.ConstructorParamTypes()...)
+		for _, scheduler := range e.Scheduler.GetAll() {
+			instances = append(instances, scheduler)
+		}
+	}
+
+	for _, instance := range instances {
+		depTypes = append(depTypes, container.InjectFieldTypes(instance)...)
+	}
+
+	baseManagerType := reflect.TypeOf((*common.IBaseManager)(nil)).Elem()
+	required := make(map[reflect.Type]bool)
+	for _, depType := range depTypes {
+		if depType.Kind() == reflect.Interface && depType.Implements(baseManagerType) {
+			required[depType] = true
+		}
+	}
+
+	// 引擎自身使用的管理器：实体需要数据库迁移，监听器和定时器需要对应的管理器驱动，
+	// 启动、停止和启动失败事件通过通知管理器发送
+	required[reflect.TypeOf((*notificationmgr.INotificationManager)(nil)).Elem()] = true
+	if e.Entity != nil && e.Entity.Count() > 0 {
+		required[reflect.TypeOf((*databasemgr.IDatabaseManager)(nil)).Elem()] = true
+	}
+	if e.Listener != nil && e.Listener.Count() > 0 {
+		required[reflect.TypeOf((*mqmgr.IMQManager)(nil)).Elem()] = true
+	}
+	if e.Scheduler != nil && e.Scheduler.Count() > 0 {
+		required[reflect.TypeOf((*schedulermgr.ISchedulerManager)(nil)).Elem()] = true
+	}
+	return required
+}
+
 // autoInject 自动依赖注入
-func (e *Engine) autoInject() error {
+// 按层依次注入，某一层注入失败时继续注入其余层，所有问题汇总到 problems
+func (e *Engine) autoInject(problems *startupProblems) {
 	e.logPhaseStart(PhaseInjection, "Starting dependency injection")
 
 	// 1. Entity 层（无需依赖注入）
 
+	totalCount := 0
+	inject := func(layer string, injectAll func() error, names func() []string) {
+		if err := injectAll(); err != nil {
+			problems.add(strings.ToLower(layer)+" inject failed", err)
+			return
+		}
+		for _, name := range names() {
+			e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", layer, name))
+			totalCount++
+		}
+	}
+
 	// 2. Repository 层（依赖 Manager + Entity）
 	e.Repository.SetManagerContainer(e.Manager)
-	if err := e.Repository.InjectAll(); err != nil {
-		return fmt.Errorf("repository inject failed: %w", err)
-	}
-	repos := e.Repository.GetAll()
-	for _, repo := range repos {
-		e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", "Repository", repo.RepositoryName()))
-	}
+	inject("Repository", e.Repository.InjectAll, func() []string {
+		var names []string
+		for _, repo := range e.Repository.GetAll() {
+			names = append(names, repo.RepositoryName())
+		}
+		return names
+	})
 
 	// 3. Service 层（依赖 Manager + Repository + 同层）
 	e.Service.SetManagerContainer(e.Manager)
-	if err := e.Service.InjectAll(); err != nil {
-		return fmt.Errorf("service inject failed: %w", err)
-	}
-	svcs := e.Service.GetAll()
-	for _, svc := range svcs {
-		e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", "Service", svc.ServiceName()))
-	}
+	inject("Service", e.Service.InjectAll, func() []string {
+		var names []string
+		for _, svc := range e.Service.GetAll() {
+			names = append(names, svc.ServiceName())
+		}
+		return names
+	})
 
 	// 4. Controller 层（依赖 Manager + Service）
 	e.Controller.SetManagerContainer(e.Manager)
-	if err := e.Controller.InjectAll(); err != nil {
-		return fmt.Errorf("controller inject failed: %w", err)
-	}
-	ctrls := e.Controller.GetAll()
-	for _, ctrl := range ctrls {
-		e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", "Controller", ctrl.ControllerName()))
-	}
+	inject("Controller", e.Controller.InjectAll, func() []string {
+		var names []string
+		for _, ctrl := range e.Controller.GetAll() {
+			names = append(names, ctrl.ControllerName())
+		}
+		return names
+	})
 
 	// 5. Middleware 层（依赖 Manager + Service）
 	e.Middleware.SetManagerContainer(e.Manager)
-	if err := e.Middleware.InjectAll(); err != nil {
-		return fmt.Errorf("middleware inject failed: %w", err)
-	}
-	mws := e.Middleware.GetAll()
-	for _, mw := range mws {
-		e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", "Middleware", mw.MiddlewareName()))
-	}
+	inject("Middleware", e.Middleware.InjectAll, func() []string {
+		var names []string
+		for _, mw := range e.Middleware.GetAll() {
+			names = append(names, mw.MiddlewareName())
+		}
+		return names
+	})
 
 	// 6. Listener 层
 	if e.Listener != nil {
 		e.Listener.SetManagerContainer(e.Manager)
-		if err := e.Listener.InjectAll(); err != nil {
-			return fmt.Errorf("listener inject failed: %w", err)
-		}
-		listeners := e.Listener.GetAll()
-		for _, listener := range listeners {
-			e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", "Listener", listener.ListenerName()))
-		}
+		inject("Listener", e.Listener.InjectAll, func() []string {
+			var names []string
+			for _, listener := range e.Listener.GetAll() {
+				names = append(names, listener.ListenerName())
+			}
+			return names
+		})
 	}
 
-	// 7. Scheduler 层（新增）
+	// 7. Scheduler 层
 	if e.Scheduler != nil {
 		e.Scheduler.SetManagerContainer(e.Manager)
-		if err := e.Scheduler.InjectAll(); err != nil {
-			return fmt.Errorf("scheduler inject failed: %w", err)
-		}
-		schedulers := e.Scheduler.GetAll()
-		for _, scheduler := range schedulers {
-			e.logStartup(PhaseInjection, fmt.Sprintf("[%s layer] %s: injection complete", "Scheduler", scheduler.SchedulerName()))
-		}
+		inject("Scheduler", e.Scheduler.InjectAll, func() []string {
+			var names []string
+			for _, scheduler := range e.Scheduler.GetAll() {
+				names = append(names, scheduler.SchedulerName())
+			}
+			return names
+		})
 	}
 
-	totalCount := len(repos) + len(svcs) + len(ctrls) + len(mws)
-	if e.Listener != nil {
-		totalCount += len(e.Listener.GetAll())
-	}
-	if e.Scheduler != nil {
-		totalCount += len(e.Scheduler.GetAll())
-	}
 	e.logPhaseEnd(PhaseInjection, "Dependency injection complete", logger.F("count", totalCount))
-
-	return nil
 }
 
 // Start 启动引擎（实现 liteServer 接口）
@@ -429,6 +571,94 @@ func (e *Engine) Start() error {
 		return fmt.Errorf("engine already started")
 	}
 
+	if err := e.startComponents(); err != nil {
+		return err
+	}
+
+	// 6. 启动 HTTP 服务器（平滑重启产生的新进程直接继承父进程的监听器）
+	var (
+		ln        net.Listener
+		inherited bool
+		err       error
+	)
+	if e.serverConfig.UnixSocket.Enabled() {
+		ln, inherited, err = listenUnix(listenerHTTP, e.serverConfig.UnixSocket)
+	} else {
+		ln, inherited, err = listen(listenerHTTP, "tcp", e.httpServer.Addr)
+	}
+	if err != nil {
+		return e.rollbackStart(fmt.Errorf("HTTP server failed to start: %w", err))
+	}
+	e.listeners = []namedListener{{name: listenerHTTP, listener: ln}}
+	e.handedOff = false
+	e.logger().Info("HTTP server listening", "network", e.serverConfig.Network(), "addr", ln.Addr().String(), "inherited", inherited, "tls", e.tlsCerts != nil, "h2c", e.serverConfig.H2C)
+
+	if e.tlsCerts != nil {
+		go e.tlsCerts.watch(e.logger)
+	}
+
+	errChan := make(chan error, 2)
+	go func() {
+		serve := e.httpServer.Serve
+		if e.tlsCerts != nil {
+			// 证书由 TLSConfig.GetCertificate 提供
+			serve = func(ln net.Listener) error { return e.httpServer.ServeTLS(ln, "", "") }
+		}
+		if err := serve(ln); err != nil && err != http.ErrServerClosed {
+			e.logger().Error("HTTP server error", "error", err)
+			errChan <- fmt.Errorf("HTTP server error: %w", err)
+		}
+	}()
+
+	// 启动管理端 HTTP 服务器
+	if e.adminServer != nil {
+		adminLn, err := e.startAdminServer(errChan)
+		if err != nil {
+			return e.rollbackStart(err)
+		}
+		e.listeners = append(e.listeners, namedListener{name: listenerAdmin, listener: adminLn})
+	}
+
+	select {
+	case err := <-errChan:
+		return e.rollbackStart(fmt.Errorf("HTTP server failed to start: %w", err))
+	case <-time.After(100 * time.Millisecond):
+		e.logger().Debug("HTTP server started successfully")
+	}
+
+	// 7. 启动门控：关键依赖健康后才标记就绪（期间 /api/ready 返回 not_ready）
+	if err := e.waitReadinessGate(); err != nil {
+		return e.rollbackStart(err)
+	}
+
+	// 记录启动完成汇总（含最慢的组件）
+	totalDuration := e.completeStartup()
+	e.logPhaseStart(PhaseStartup, "Service startup complete, starting to serve requests",
+		logger.F("addr", e.httpServer.Addr),
+		logger.F("total_duration", totalDuration.String()))
+
+	e.started = true
+	e.ready.Store(true)
+
+	// 检测配置文件变更以切换维护模式（配置了 server.maintenance.reload_interval 时）
+	go e.watchMaintenanceConfig()
+
+	// 平滑重启产生的新进程：通知父进程已就绪，父进程开始排空请求
+	if err := notifyParentReady(); err != nil {
+		e.logger().Warn("Failed to notify parent process", "error", err)
+	}
+
+	// 发送启动成功通知
+	e.sendNotification("started", map[string]string{
+		"地址": e.httpServer.Addr,
+		"耗时": totalDuration.String(),
+	})
+
+	return nil
+}
+
+// startComponents 按顺序启动各层组件（不含 HTTP 服务器）
+func (e *Engine) startComponents() error {
 	// 1. 启动所有 Manager
 	if err := e.startManagers(); err != nil {
 		return fmt.Errorf("start managers failed: %w", err)
@@ -436,7 +666,7 @@ func (e *Engine) Start() error {
 
 	// 2. 自动迁移数据库（如果启用）
 	if e.autoMigrateDB {
-		if err := e.autoMigrateDatabase(); err != nil {
+		if err := e.timeComponent(PhaseStartup, ComponentMigration, "database", e.autoMigrateDatabase); err != nil {
 			return fmt.Errorf("auto migrate database failed: %w", err)
 		}
 	}
@@ -472,39 +702,22 @@ func (e *Engine) Start() error {
 		e.asyncLogger = nil
 	}
 
-	// 6. 启动 HTTP 服务器
-	e.logger().Info("HTTP server listening", "addr", e.httpServer.Addr)
-
-	errChan := make(chan error, 1)
-	go func() {
-		if err := e.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			e.logger().Error("HTTP server error", "error", err)
-			errChan <- fmt.Errorf("HTTP server error: %w", err)
-		}
-	}()
+	return nil
+}
 
-	select {
-	case err := <-errChan:
-		return fmt.Errorf("HTTP server failed to start: %w", err)
-	case <-time.After(100 * time.Millisecond):
-		e.logger().Debug("HTTP server started successfully")
+// waitReadinessGate 等待关键检查通过后开始后台刷新就绪状态
+func (e *Engine) waitReadinessGate() error {
+	if e.readiness == nil {
+		return nil
 	}
-
-	// 记录启动完成汇总
-	totalDuration := time.Since(e.startupStartTime)
-	e.logPhaseStart(PhaseStartup, "Service startup complete, starting to serve requests",
-		logger.F("addr", e.httpServer.Addr),
-		logger.F("total_duration", totalDuration.String()))
-
-	e.started = true
-	e.ready = true
-
-	// 发送启动成功通知
-	e.sendNotification("started", map[string]string{
-		"地址": e.httpServer.Addr,
-		"耗时": totalDuration.String(),
-	})
-
+	if err := e.readiness.waitCritical(e.ctx); err != nil {
+		return fmt.Errorf("readiness gate failed: %w", err)
+	}
+	report := e.readiness.report()
+	if report.Status == ReadinessDegraded {
+		e.logger().Warn("Non-critical health checks failing", "checks", failingChecks(report))
+	}
+	go e.readiness.start(e.ctx)
 	return nil
 }
 
@@ -549,11 +762,17 @@ func (e *Engine) getGinEngine() *gin.Engine {
 }
 
 // registerControllers 注册所有控制器路由
+// 不同控制器在同一监听器上重复注册相同方法和路径时返回错误（汇总所有冲突），而不是由 gin 触发 panic
 func (e *Engine) registerControllers() error {
 	e.logPhaseStart(PhaseRouter, "Starting to register routes")
 
+	if e.routeRegistry == nil {
+		e.routeRegistry = newRouteRegistry()
+	}
+
 	controllers := e.Controller.GetAll()
 	registeredCount := 0
+	var errs []error
 
 	for _, ctrl := range controllers {
 		route := ctrl.GetRouter()
@@ -561,14 +780,23 @@ func (e *Engine) registerControllers() error {
 			continue
 		}
 
-		// 非 debug 模式下，禁止注册 pprof 路由，防止生产环境泄露运行时信息
-		if strings.HasPrefix(route, "/debug/pprof") && e.serverConfig.Mode != "debug" {
+		router, onAdmin := e.routerFor(ctrl)
+
+		// 非 debug 模式下，禁止在公共端口注册 pprof 路由，防止生产环境泄露运行时信息
+		if strings.HasPrefix(route, "/debug/pprof") && e.serverConfig.Mode != "debug" && !onAdmin {
 			e.logStartup(PhaseRouter, "Skipped pprof route in non-debug mode",
 				logger.F("controller", ctrl.ControllerName()),
 				logger.F("mode", e.serverConfig.Mode))
 			continue
 		}
 
+		group := ""
+		if grouped, ok := ctrl.(common.IRouteGroupController); ok {
+			group = normalizeGroup(grouped.RouteGroup())
+		}
+
+		limits := e.routeLimitsFor(ctrl)
+
 		routes := strings.Split(route, ",")
 		for _, r := range routes {
 			methodStr, path, err := parseRoute(strings.TrimSpace(r))
@@ -579,24 +807,61 @@ func (e *Engine) registerControllers() error {
 				continue
 			}
 
-			handler := ctrl.Handle
+			fullPath := joinRoutePath(group, path)
+			scoped := e.scopedMiddlewaresFor(group, fullPath)
+			handlers := limits.handlers()
+			for _, mw := range scoped {
+				handlers = append(handlers, mw.handler)
+			}
+			handlers = append(handlers, ctrl.Handle)
+			middlewareNames := e.routeMiddlewareNames(onAdmin, scoped)
+
 			methods := strings.Split(methodStr, "|")
 			for _, method := range methods {
-				method = strings.TrimSpace(method)
-				e.registerRoute(method, path, handler)
+				method = normalizeRouteMethod(method)
+				if err := e.routeRegistry.add(RouteInfo{
+					Method:       method,
+					Path:         fullPath,
+					Controller:   ctrl.ControllerName(),
+					Group:        group,
+					Middlewares:  middlewareNames,
+					Admin:        onAdmin,
+					Timeout:      limits.timeout,
+					MaxBodyBytes: limits.maxBodyBytes,
+				}); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if err := registerRouteSafely(router, method, fullPath, handlers...); err != nil {
+					errs = append(errs, fmt.Errorf("controller %s: %w", ctrl.ControllerName(), err))
+					continue
+				}
 				e.logStartup(PhaseRouter, "Registered route",
-					logger.F("method", strings.ToUpper(method)),
-					logger.F("path", path),
+					logger.F("method", method),
+					logger.F("path", fullPath),
+					logger.F("admin", onAdmin),
+					logger.F("group", group),
+					logger.F("middlewares", strings.Join(middlewareNames, ",")),
 					logger.F("controller", ctrl.ControllerName()))
 				registeredCount++
 			}
 		}
 	}
 
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
 	e.logPhaseEnd(PhaseRouter, "Route registration complete",
 		logger.F("route_count", registeredCount),
 		logger.F("controller_count", len(controllers)))
 
+	// 输出启动路由表
+	routeTable := make([]RouteInfo, len(e.routeRegistry.routes))
+	copy(routeTable, e.routeRegistry.routes)
+	sortRoutes(routeTable)
+	e.logStartup(PhaseRouter, "Route table:\n"+formatRouteTable(routeTable))
+
 	return nil
 }
 
@@ -636,13 +901,64 @@ func (e *Engine) initializeGinEngineServices() {
 	}
 }
 
+// initializeHealthCheckerAware 为实现 IHealthCheckerAware 的控制器传入健康检查者
+func (e *Engine) initializeHealthCheckerAware(checkers map[string]common.IHealthChecker) {
+	if e.Controller == nil {
+		return
+	}
+	for _, ctrl := range e.Controller.GetAll() {
+		if aware, ok := ctrl.(common.IHealthCheckerAware); ok {
+			aware.SetHealthCheckers(checkers)
+		}
+	}
+}
+
+// watchedSignals 返回 WaitForShutdown 捕获的信号：关闭信号，以及按配置启用的平滑重启、维护模式信号
+func (e *Engine) watchedSignals() []os.Signal {
+	sigs := []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT}
+	if e.serverConfig.GracefulRestart {
+		sigs = append(sigs, restartSignals...)
+	}
+	if e.maintenanceSignalEnabled() {
+		sigs = append(sigs, maintenanceSignals...)
+	}
+	return sigs
+}
+
+// maintenanceSignalEnabled 是否通过信号切换维护模式（server.maintenance.signal）
+func (e *Engine) maintenanceSignalEnabled() bool {
+	return e.serverConfig.Maintenance != nil && e.serverConfig.Maintenance.Signal
+}
+
 // WaitForShutdown 等待关闭信号
+// 收到 SIGINT/SIGTERM/SIGQUIT 时优雅关闭；启用 server.graceful_restart 时，
+// 收到 SIGHUP/SIGUSR2 会启动继承监听器的新进程，待其就绪后排空当前进程的请求并退出；
+// 启用 server.maintenance.signal 时，收到 SIGUSR1 切换维护模式
 func (e *Engine) WaitForShutdown() {
 	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	signal.Notify(sigs, e.watchedSignals()...)
+	defer signal.Stop(sigs)
+	maintenanceSignal := e.maintenanceSignalEnabled()
+
+	var sig os.Signal
+	for sig = range sigs {
+		if maintenanceSignal && containsSignal(maintenanceSignals, sig) {
+			e.logger().Info("Received maintenance signal", "signal", sig)
+			e.toggleMaintenance()
+			continue
+		}
+		if !isRestartSignal(sig) {
+			e.logger().Info("Received shutdown signal", "signal", sig)
+			break
+		}
 
-	sig := <-sigs
-	e.logger().Info("Received shutdown signal", "signal", sig)
+		e.logger().Info("Received restart signal", "signal", sig)
+		if err := e.gracefulRestart(); err != nil {
+			e.logger().Error("Graceful restart failed, current process keeps serving", "error", err)
+			continue
+		}
+		break
+	}
 
 	// 发送正在停止通知
 	e.sendNotification("stopping", map[string]string{
@@ -654,3 +970,18 @@ func (e *Engine) WaitForShutdown() {
 		os.Exit(1)
 	}
 }
+
+// isRestartSignal 判断是否为平滑重启信号
+func isRestartSignal(sig os.Signal) bool {
+	return containsSignal(restartSignals, sig)
+}
+
+// containsSignal 判断信号是否在列表中
+func containsSignal(signals []os.Signal, sig os.Signal) bool {
+	for _, s := range signals {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
@@ -523,3 +523,73 @@ scheduler:
 		_ = engine.Stop()
 	})
 }
+
+// TestEngineInitialize_InvalidServerConfig 测试 server 配置错误时初始化失败
+func TestEngineInitialize_InvalidServerConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	configFile := `server:
+  port: "8080"
+  read_timeout: "10"
+  enable_recovery: true
+  enable_recover: true
+telemetry:
+  driver: none
+logger:
+  driver: none
+database:
+  driver: none
+cache:
+  driver: none
+lock:
+  driver: memory
+  memory_config:
+    ttl: 60
+limiter:
+  driver: memory
+  memory_config:
+    max_requests: 1000
+    window: 60
+mq:
+  driver: memory
+  memory_config:
+    max_queue_size: 10000
+    channel_buffer: 100
+scheduler:
+  driver: cron
+  cron_config:
+    validate_on_startup: true
+`
+	configPath := t.TempDir() + "/test-invalid-server-config.yaml"
+	if err := os.WriteFile(configPath, []byte(configFile), 0644); err != nil {
+		t.Fatalf("创建配置文件失败: %v", err)
+	}
+
+	entityContainer := container.NewEntityContainer()
+	repositoryContainer := container.NewRepositoryContainer(entityContainer)
+	serviceContainer := container.NewServiceContainer(repositoryContainer)
+
+	engine := NewEngine(
+		&BuiltinConfig{Driver: "yaml", FilePath: configPath},
+		entityContainer,
+		repositoryContainer,
+		serviceContainer,
+		container.NewControllerContainer(serviceContainer),
+		container.NewMiddlewareContainer(serviceContainer),
+		nil,
+		nil,
+	)
+
+	err := engine.Initialize()
+	if err == nil {
+		t.Fatal("期望初始化失败")
+	}
+	for _, key := range []string{"server.port", "server.read_timeout", "server.enable_recover"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("期望错误包含 %s, 实际 %q", key, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "server.enable_recovery") {
+		t.Errorf("已废弃键不应报错, 实际 %q", err.Error())
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	playgroundvalidator "github.com/go-playground/validator/v10"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/util/validator"
+)
+
+// installErrorHandling 为 Gin 引擎安装统一错误处理：错误处理中间件（最外层）、NoRoute 和 NoMethod 处理器
+// 所有错误（含未匹配路由、方法不允许、验证失败、panic）统一渲染为 APIErrorResponse
+func (e *Engine) installErrorHandling(router *gin.Engine) {
+	router.HandleMethodNotAllowed = true
+	router.Use(e.errorHandler())
+	router.NoRoute(func(c *gin.Context) {
+		e.logger().Warn("Route not found", "path", c.Request.URL.Path, "method", c.Request.Method)
+		_ = c.Error(common.NewNotFound("route not found").WithDetails(gin.H{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+		}))
+	})
+	router.NoMethod(func(c *gin.Context) {
+		_ = c.Error(common.NewBusinessError(common.CodeMethodNotAllowed, "method not allowed").WithDetails(gin.H{
+			"path":   c.Request.URL.Path,
+			"method": c.Request.Method,
+		}))
+	})
+}
+
+// errorHandler 错误处理中间件
+// 恢复处理链中的 panic，并在处理链结束且未写出响应时渲染通过 ctx.Error 提交的最后一个错误
+func (e *Engine) errorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				e.logger().Error("Panic recovered",
+					"panic", r,
+					"method", c.Request.Method,
+					"path", c.Request.URL.Path,
+					"stack", string(debug.Stack()))
+				if !c.Writer.Written() {
+					e.renderError(c, common.NewInternal("internal server error").Wrap(fmt.Errorf("panic: %v", r)))
+				}
+				c.Abort()
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		e.renderError(c, c.Errors.Last())
+	}
+}
+
+// renderError 将错误映射为业务错误并写出统一错误响应，服务端错误记录日志
+func (e *Engine) renderError(c *gin.Context, err error) {
+	bizErr := toBusinessError(err)
+	if bizErr.Status >= http.StatusInternalServerError {
+		e.logger().Error("Request failed",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", bizErr.Status,
+			"error", err.Error())
+	}
+	c.AbortWithStatusJSON(bizErr.Status, bizErr.Response())
+}
+
+// toBusinessError 将错误映射为业务错误
+//   - *common.BusinessError：原样使用
+//   - 验证错误（util/validator、go-playground/validator）：400，details 为字段错误列表
+//   - 请求体 JSON 解析错误（含不完整的 JSON）、gin 绑定错误：400
+//   - 请求体超过大小限制：413
+//   - 请求 context 超时（context.DeadlineExceeded）：408
+//   - 其他错误：500，不向客户端暴露错误内容
+func toBusinessError(err error) *common.BusinessError {
+	if bizErr, ok := common.AsBusinessError(err); ok {
+		return bizErr
+	}
+
+	var validationErr *validator.ValidationError
+	if errors.As(err, &validationErr) {
+		return common.NewBadRequest(validationErr.Message).WithDetails(validationErr.Fields).Wrap(err)
+	}
+	var fieldErrs playgroundvalidator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		return common.NewBadRequest(fieldErrs.Error()).Wrap(err)
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return errPayloadTooLarge().Wrap(err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errRequestTimeout().Wrap(err)
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return common.NewBadRequest("invalid request body").Wrap(err)
+	}
+	var ginErr *gin.Error
+	if errors.As(err, &ginErr) && ginErr.IsType(gin.ErrorTypeBind) {
+		return common.NewBadRequest("invalid request").Wrap(err)
+	}
+
+	return common.NewInternal("internal server error").Wrap(err)
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/util/validator"
+)
+
+// newErrorTestEngine 创建安装了统一错误处理的测试引擎，handler 挂载到 /api/test
+func newErrorTestEngine(t *testing.T, method string, handler gin.HandlerFunc) *Engine {
+	t.Helper()
+	engine := newRouteTestEngine(t)
+	engine.installErrorHandling(engine.ginEngine)
+	engine.ginEngine.Handle(method, "/api/test", handler)
+	return engine
+}
+
+// TestErrorHandler 测试统一错误处理
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    gin.HandlerFunc
+		wantStatus int
+		wantCode   common.BusinessErrorCode
+	}{
+		{
+			name:       "业务错误",
+			handler:    func(c *gin.Context) { _ = c.Error(common.NewConflict("user exists").Wrap(errors.New("dup"))) },
+			wantStatus: http.StatusConflict,
+			wantCode:   common.CodeConflict,
+		},
+		{
+			name:       "包装的业务错误",
+			handler:    func(c *gin.Context) { _ = c.Error(errors.Join(errors.New("ctx"), common.NewForbidden("denied"))) },
+			wantStatus: http.StatusForbidden,
+			wantCode:   common.CodeForbidden,
+		},
+		{
+			name:       "普通错误_返回500",
+			handler:    func(c *gin.Context) { _ = c.Error(errors.New("db down")) },
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   common.CodeInternalError,
+		},
+		{
+			name:       "context超时_返回408",
+			handler:    func(c *gin.Context) { _ = c.Error(fmt.Errorf("query users: %w", context.DeadlineExceeded)) },
+			wantStatus: http.StatusRequestTimeout,
+			wantCode:   common.CodeRequestTimeout,
+		},
+		{
+			name:       "panic_返回500",
+			handler:    func(c *gin.Context) { panic("boom") },
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   common.CodeInternalError,
+		},
+		{
+			name: "JSON解析错误_返回400",
+			handler: func(c *gin.Context) {
+				var body struct{ Name string }
+				if err := c.ShouldBindJSON(&body); err != nil {
+					_ = c.Error(err)
+				}
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   common.CodeBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newErrorTestEngine(t, http.MethodPost, tt.handler)
+			w := httptest.NewRecorder()
+			engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/test", bytes.NewBufferString("{")))
+			assertAPIError(t, w, tt.wantStatus, tt.wantCode)
+		})
+	}
+
+	t.Run("已写出响应_不覆盖", func(t *testing.T) {
+		engine := newErrorTestEngine(t, http.MethodGet, func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+			_ = c.Error(errors.New("late error"))
+		})
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/test", nil))
+		if w.Code != http.StatusOK || w.Body.String() != "ok" {
+			t.Errorf("响应被覆盖: %d %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+// TestErrorHandler_Validation 测试验证错误的渲染
+func TestErrorHandler_Validation(t *testing.T) {
+	v := validator.NewDefaultValidator()
+	engine := newErrorTestEngine(t, http.MethodPost, func(c *gin.Context) {
+		var req struct {
+			Name string `json:"name" validate:"required"`
+		}
+		if err := v.Validate(c, &req); err != nil {
+			_ = c.Error(err)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/test", bytes.NewBufferString(`{"name":""}`)))
+	assertAPIError(t, w, http.StatusBadRequest, common.CodeBadRequest)
+
+	var resp struct {
+		Details []validator.FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if len(resp.Details) != 1 || resp.Details[0].Field != "name" || resp.Details[0].Tag != "required" {
+		t.Errorf("字段错误不正确: %+v", resp.Details)
+	}
+}
+
+// TestErrorHandler_NoRouteNoMethod 测试未匹配路由和方法不匹配
+func TestErrorHandler_NoRouteNoMethod(t *testing.T) {
+	engine := newErrorTestEngine(t, http.MethodGet, func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	t.Run("未匹配路由_返回404", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/unknown", nil))
+		assertAPIError(t, w, http.StatusNotFound, common.CodeNotFound)
+	})
+
+	t.Run("方法不匹配_返回405", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/test", nil))
+		assertAPIError(t, w, http.StatusMethodNotAllowed, common.CodeMethodNotAllowed)
+	})
+}
+
+// TestErrorHandler_Admin 测试管理端使用相同的错误格式
+func TestErrorHandler_Admin(t *testing.T) {
+	engine := newAdminTestEngine(t, true, "release")
+	w := httptest.NewRecorder()
+	engine.adminEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/unknown", nil))
+	assertAPIError(t, w, http.StatusNotFound, common.CodeNotFound)
+}
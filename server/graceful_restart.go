@@ -0,0 +1,145 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/lite-lake/litecore-go/logger"
+)
+
+// fileListener 可导出文件描述符的监听器（*net.TCPListener、*net.UnixListener）
+type fileListener interface {
+	File() (*os.File, error)
+}
+
+// gracefulRestart 平滑重启
+// 启动继承当前监听器的新进程，等待其就绪后将当前进程标记为未就绪；
+// 调用方随后执行 Stop，在 shutdown_timeout 内排空进行中的请求。
+// 新进程启动失败或未在 shutdown_timeout 内就绪时返回错误，当前进程继续提供服务。
+func (e *Engine) gracefulRestart() error {
+	e.mu.RLock()
+	listeners := make([]namedListener, len(e.listeners))
+	copy(listeners, e.listeners)
+	timeout := e.shutdownTimeout
+	e.mu.RUnlock()
+
+	if len(listeners) == 0 {
+		return fmt.Errorf("no active listener to hand off")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to resolve executable: %w", err)
+	}
+
+	files := make([]*os.File, 0, len(listeners)+1)
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	specs := make([]string, 0, len(listeners))
+	for _, nl := range listeners {
+		fl, ok := nl.listener.(fileListener)
+		if !ok {
+			return fmt.Errorf("listener %s (%T) does not support fd handoff", nl.name, nl.listener)
+		}
+		f, err := fl.File()
+		if err != nil {
+			return fmt.Errorf("failed to get fd of listener %s: %w", nl.name, err)
+		}
+		// ExtraFiles 中第 i 个文件在子进程中的描述符为 3+i
+		specs = append(specs, fmt.Sprintf("%s:%d", nl.name, 3+len(files)))
+		files = append(files, f)
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("failed to create ready pipe: %w", err)
+	}
+	defer readyR.Close()
+	readyFD := 3 + len(files)
+	files = append(files, readyW)
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(restartEnviron(os.Environ()),
+		envInheritedListeners+"="+strings.Join(specs, ","),
+		fmt.Sprintf("%s=%d", envRestartReadyFD, readyFD),
+	)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start new process: %w", err)
+	}
+	// 关闭父进程持有的写端，子进程退出时读端可感知 EOF
+	_ = readyW.Close()
+
+	e.logger().Info("New process started, waiting for it to become ready",
+		logger.F("pid", cmd.Process.Pid),
+		logger.F("listeners", strings.Join(specs, ",")),
+		logger.F("timeout", timeout.String()))
+
+	readyCh := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		_, err := readyR.Read(buf)
+		readyCh <- err
+	}()
+
+	exitCh := make(chan error, 1)
+	go func() {
+		exitCh <- cmd.Wait()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-readyCh:
+		if err != nil {
+			_ = cmd.Process.Kill()
+			return fmt.Errorf("new process exited before ready: %w", err)
+		}
+	case err := <-exitCh:
+		return exitedBeforeReadyError(err)
+	case <-timer.C:
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("new process not ready within %s", timeout)
+	}
+
+	e.ready.Store(false)
+	e.mu.Lock()
+	e.handedOff = true
+	e.mu.Unlock()
+
+	e.logger().Info("New process is ready, draining current process", logger.F("pid", cmd.Process.Pid))
+	return nil
+}
+
+// exitedBeforeReadyError 新进程在就绪前退出的错误：正常退出（err 为 nil）时注明退出状态 0，否则包装退出错误
+func exitedBeforeReadyError(err error) error {
+	if err == nil {
+		return errors.New("new process exited with status 0 before ready")
+	}
+	return fmt.Errorf("new process exited before ready: %w", err)
+}
+
+// restartEnviron 过滤掉上一次重启遗留的继承变量
+func restartEnviron(environ []string) []string {
+	result := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, envInheritedListeners+"=") || strings.HasPrefix(kv, envRestartReadyFD+"=") {
+			continue
+		}
+		result = append(result, kv)
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lite-lake/litecore-go/logger"
+)
+
+// Handler 返回处理请求的 http.Handler（需在 Initialize 之后调用）
+// 可直接用于 httptest.NewRecorder / httptest.NewServer
+func (e *Engine) Handler() http.Handler {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.httpServer == nil {
+		return nil
+	}
+	return e.httpServer.Handler
+}
+
+// StartInProcess 启动各层组件并标记就绪，但不监听任何端口
+// 请求通过 Handler() 在进程内处理，用于 servertest 等测试场景；停止时同样调用 Stop
+func (e *Engine) StartInProcess() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.started {
+		return fmt.Errorf("engine already started")
+	}
+	if e.httpServer == nil {
+		return fmt.Errorf("engine not initialized")
+	}
+
+	if err := e.startComponents(); err != nil {
+		return err
+	}
+	if err := e.waitReadinessGate(); err != nil {
+		return e.rollbackStart(err)
+	}
+
+	e.logPhaseStart(PhaseStartup, "Service startup complete, serving requests in process",
+		logger.F("total_duration", e.completeStartup().String()))
+
+	e.started = true
+	e.ready.Store(true)
+	return nil
+}
@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/lite-lake/litecore-go/common"
@@ -75,7 +74,9 @@ func (e *Engine) logPhaseStart(phase StartupPhase, msg string, fields ...logger.
 // logPhaseEnd 记录阶段结束（带耗时）
 func (e *Engine) logPhaseEnd(phase StartupPhase, msg string, extraFields ...logger.Field) {
 	duration := time.Since(e.phaseStartTimes[phase])
-	e.phaseDurations[phase] = duration
+	if e.startup != nil && phase <= PhaseStartup {
+		e.startup.addPhase(phase, duration)
+	}
 
 	fields := append(extraFields,
 		logger.F("duration", duration.String()),
@@ -89,10 +90,11 @@ func (e *Engine) startManagers() error {
 	managers := e.Manager.GetAll()
 
 	for _, mgr := range managers {
-		if err := mgr.(common.IBaseManager).OnStart(); err != nil {
-			return fmt.Errorf("failed to start manager %s: %w", mgr.(common.IBaseManager).ManagerName(), err)
+		m := mgr.(common.IBaseManager)
+		if err := e.timeComponent(PhaseStartup, ComponentManagerStart, m.ManagerName(), m.OnStart); err != nil {
+			return fmt.Errorf("failed to start manager %s: %w", m.ManagerName(), err)
 		}
-		e.logStartup(PhaseStartup, mgr.(common.IBaseManager).ManagerName()+": started")
+		e.logStartup(PhaseStartup, m.ManagerName()+": started")
 	}
 
 	e.logPhaseEnd(PhaseStartup, "Manager layer started", logger.F("count", len(managers)))
@@ -105,7 +107,7 @@ func (e *Engine) startRepositories() error {
 	repositories := e.Repository.GetAll()
 
 	for _, repo := range repositories {
-		if err := repo.OnStart(); err != nil {
+		if err := e.timeComponent(PhaseStartup, ComponentRepositoryStart, repo.RepositoryName(), repo.OnStart); err != nil {
 			return fmt.Errorf("failed to start repository %s: %w", repo.RepositoryName(), err)
 		}
 		e.logStartup(PhaseStartup, repo.RepositoryName()+": started")
@@ -124,7 +126,7 @@ func (e *Engine) startServices() error {
 	}
 
 	for _, svc := range services {
-		if err := svc.OnStart(); err != nil {
+		if err := e.timeComponent(PhaseStartup, ComponentServiceStart, svc.ServiceName(), svc.OnStart); err != nil {
 			return fmt.Errorf("failed to start service %s: %w", svc.ServiceName(), err)
 		}
 		e.logStartup(PhaseStartup, svc.ServiceName()+": started")
@@ -140,7 +142,7 @@ func (e *Engine) startMiddlewares() error {
 	middlewares := e.Middleware.GetAll()
 
 	for _, mw := range middlewares {
-		if err := mw.OnStart(); err != nil {
+		if err := e.timeComponent(PhaseStartup, ComponentMiddlewareStart, mw.MiddlewareName(), mw.OnStart); err != nil {
 			return fmt.Errorf("failed to start middleware %s: %w", mw.MiddlewareName(), err)
 		}
 		e.logStartup(PhaseStartup, mw.MiddlewareName()+": started")
@@ -170,6 +172,10 @@ func (e *Engine) startListeners() error {
 		return fmt.Errorf("MQManager 未初始化，但存在 %d 个 Listener: %w", len(listeners), err)
 	}
 
+	// 订阅使用独立的 ctx，关闭时先停止消费再排空 HTTP 请求
+	consumeCtx, stopConsuming := context.WithCancel(e.ctx)
+	e.stopConsuming = stopConsuming
+
 	startedCount := 0
 
 	for _, listener := range listeners {
@@ -188,15 +194,25 @@ func (e *Engine) startListeners() error {
 		}
 
 		wrapper := func(ctx context.Context, msg mqmgr.Message) error {
-			return listener.Handle(ctx, msg)
+			// 维护模式暂停工作者期间阻塞消费，恢复后继续处理
+			if e.maintenance != nil {
+				if err := e.maintenance.waitResume(ctx); err != nil {
+					return err
+				}
+			}
+			handlerCtx, done := e.listenerTasks.track(ctx)
+			defer done()
+			return listener.Handle(handlerCtx, msg)
 		}
 
-		err := mqManager.SubscribeWithCallback(
-			e.ctx,
-			queue,
-			wrapper,
-			subscribeOpts...,
-		)
+		err := e.timeComponent(PhaseStartup, ComponentListenerStart, listener.ListenerName(), func() error {
+			return mqManager.SubscribeWithCallback(
+				consumeCtx,
+				queue,
+				wrapper,
+				subscribeOpts...,
+			)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to start listener %s: %w", listener.ListenerName(), err)
 		}
@@ -230,6 +246,7 @@ func (e *Engine) startSchedulers() error {
 	}
 
 	startedCount := 0
+	e.registeredSchedulers = make(map[string]common.IBaseScheduler, len(schedulers))
 
 	for _, scheduler := range schedulers {
 		e.getLogger().Info("Registering scheduler",
@@ -237,11 +254,17 @@ func (e *Engine) startSchedulers() error {
 			logger.F("rule", scheduler.GetRule()),
 			logger.F("timezone", scheduler.GetTimezone()))
 
-		if err := schedulerMgr.RegisterScheduler(scheduler); err != nil {
+		// 包装为维护模式暂停工作者期间跳过执行的定时器
+		registered := common.IBaseScheduler(scheduler)
+		if e.maintenance != nil {
+			registered = &pausableScheduler{IBaseScheduler: scheduler, mode: e.maintenance, log: e.logger}
+		}
+		if err := schedulerMgr.RegisterScheduler(registered); err != nil {
 			return fmt.Errorf("Failed to register scheduler %s: %w", scheduler.SchedulerName(), err)
 		}
+		e.registeredSchedulers[scheduler.SchedulerName()] = registered
 
-		if err := scheduler.OnStart(); err != nil {
+		if err := e.timeComponent(PhaseStartup, ComponentSchedulerStart, scheduler.SchedulerName(), scheduler.OnStart); err != nil {
 			return fmt.Errorf("Failed to start scheduler %s: %w", scheduler.SchedulerName(), err)
 		}
 
@@ -342,7 +365,11 @@ func (e *Engine) stopSchedulers() []error {
 			continue
 		}
 
-		if err := schedulerMgr.UnregisterScheduler(scheduler); err != nil {
+		registered, ok := e.registeredSchedulers[scheduler.SchedulerName()]
+		if !ok {
+			registered = scheduler
+		}
+		if err := schedulerMgr.UnregisterScheduler(registered); err != nil {
 			errors = append(errors, fmt.Errorf("Failed to unregister scheduler %s: %w", scheduler.SchedulerName(), err))
 		}
 	}
@@ -351,6 +378,9 @@ func (e *Engine) stopSchedulers() []error {
 }
 
 // Stop 停止引擎（实现 LiteServer 接口）
+// 按阶段依次关闭，每个阶段受各自超时限制，超时后记录错误并继续下一阶段：
+// 标记未就绪 → 等待负载均衡摘除 → 停止监听器/定时器并等待处理中的任务 → 排空 HTTP 请求 → 停止服务 → 停止管理器；
+// 某个阶段超时后经过宽限期仍未结束时，跳过其后的阶段，避免与其并发访问同一资源
 func (e *Engine) Stop() error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -359,68 +389,101 @@ func (e *Engine) Stop() error {
 		return nil
 	}
 
-	e.logStartup(PhaseShutdown, "Shutting down HTTP server...")
-
-	e.ready = false
+	e.logPhaseStart(PhaseShutdown, "Shutting down...")
+	report := e.runShutdownPhases(false)
+	e.shutdownMu.Lock()
+	e.shutdownReport = report
+	e.shutdownMu.Unlock()
+	e.logShutdownReport(report)
 
-	ctx, cancel := context.WithTimeout(context.Background(), e.shutdownTimeout)
-	defer cancel()
-
-	if e.httpServer != nil {
-		if err := e.httpServer.Shutdown(ctx); err != nil {
-			return fmt.Errorf("HTTP server shutdown error: %w", err)
-		}
+	if err := report.Err(); err != nil {
+		return err
 	}
 
-	e.logPhaseStart(PhaseShutdown, "Stopping all layers")
-
-	middlewareErrors := e.stopMiddlewares()
-	e.logStartup(PhaseShutdown, "Middleware layer stopped")
+	e.started = false
+	return nil
+}
 
-	listenerErrors := e.stopListeners()
-	e.logStartup(PhaseShutdown, "Listener layer stopped")
+// runShutdownPhases 依次执行关闭阶段并返回关闭报告
+// rollback 为 true 时用于启动失败后的回滚：不等待负载均衡摘除，也不发送已停止通知
+func (e *Engine) runShutdownPhases(rollback bool) ShutdownReport {
+	cfg := e.serverConfig.Shutdown
+	if cfg == nil {
+		cfg = DefaultShutdownConfig()
+	}
 
-	schedulerErrors := e.stopSchedulers()
-	e.logStartup(PhaseShutdown, "Scheduler layer stopped")
+	report := ShutdownReport{StartedAt: time.Now()}
+	var blocker string // 仍在运行的阶段
+	phase := func(name string, timeout time.Duration, fn func(ctx context.Context) []error) {
+		if blocker != "" {
+			report.Phases = append(report.Phases, skippedShutdownPhase(name, blocker))
+			return
+		}
+		result := runShutdownPhase(name, timeout, fn)
+		if result.Abandoned {
+			blocker = name
+		}
+		report.Phases = append(report.Phases, result)
+	}
 
-	serviceErrors := e.stopServices()
-	e.logStartup(PhaseShutdown, "Service layer stopped")
+	phase(ShutdownPhaseNotReady, 0, func(context.Context) []error {
+		e.ready.Store(false)
+		if e.readiness != nil {
+			e.readiness.stop()
+		}
+		if e.maintenance != nil {
+			e.maintenance.stop()
+		}
+		return nil
+	})
 
-	repositoryErrors := e.stopRepositories()
-	e.logStartup(PhaseShutdown, "Repository layer stopped")
+	if !rollback {
+		phase(ShutdownPhaseDeregister, 0, func(context.Context) []error {
+			time.Sleep(cfg.DeregisterDelay)
+			return nil
+		})
+	}
 
-	managerErrors := e.stopManagers()
-	e.logStartup(PhaseShutdown, "Manager layer stopped")
+	phase(ShutdownPhaseWorkers, cfg.WorkerTimeout, e.shutdownWorkers)
 
-	allErrors := make([]error, 0, len(middlewareErrors)+len(listenerErrors)+len(schedulerErrors)+len(serviceErrors)+len(repositoryErrors)+len(managerErrors))
-	allErrors = append(allErrors, middlewareErrors...)
-	allErrors = append(allErrors, listenerErrors...)
-	allErrors = append(allErrors, schedulerErrors...)
-	allErrors = append(allErrors, serviceErrors...)
-	allErrors = append(allErrors, repositoryErrors...)
-	allErrors = append(allErrors, managerErrors...)
+	phase(ShutdownPhaseHTTPDrain, e.shutdownTimeout, e.shutdownHTTP)
 
-	shutdownDuration := time.Since(e.startupStartTime)
-	e.logPhaseEnd(PhaseShutdown, "Shutdown completed",
-		logger.F("error_count", len(allErrors)),
-		logger.F("total_duration", shutdownDuration.String()))
+	phase(ShutdownPhaseServices, cfg.ServiceTimeout, func(context.Context) []error {
+		errs := e.stopMiddlewares()
+		errs = append(errs, e.stopServices()...)
+		errs = append(errs, e.stopRepositories()...)
+		return errs
+	})
 
 	// 发送已停止通知（在 Manager 停止之前，确保通知管理器仍可用）
-	e.sendNotification("stopped", map[string]string{
-		"耗时":  shutdownDuration.String(),
-		"错误数": fmt.Sprintf("%d", len(allErrors)),
+	if !rollback {
+		e.sendNotification("stopped", map[string]string{
+			"耗时":  time.Since(report.StartedAt).String(),
+			"错误数": fmt.Sprintf("%d", report.ErrorCount()),
+		})
+	}
+
+	phase(ShutdownPhaseManagers, cfg.ManagerTimeout, func(context.Context) []error {
+		return e.stopManagers()
 	})
 
-	if len(allErrors) > 0 {
-		errorMessages := make([]string, len(allErrors))
-		for i, err := range allErrors {
-			errorMessages[i] = err.Error()
-		}
-		return fmt.Errorf("shutdown completed with %d error(s): %s", len(allErrors), strings.Join(errorMessages, "; "))
-	}
+	report.Duration = time.Since(report.StartedAt)
+	return report
+}
 
-	e.started = false
-	return nil
+// rollbackStart 组件启动后启动流程失败（如 HTTP 监听失败、启动门控未通过）时停止已启动的组件，返回原始错误
+// 此时 started 仍为 false，Stop 不会再执行关闭流程
+func (e *Engine) rollbackStart(cause error) error {
+	e.logger().Warn("Startup failed, stopping started components", "error", cause)
+	report := e.runShutdownPhases(true)
+	e.shutdownMu.Lock()
+	e.shutdownReport = report
+	e.shutdownMu.Unlock()
+	e.logShutdownReport(report)
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", cause, err)
+	}
+	return cause
 }
 
 // getServices 获取所有服务
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// envInheritedListeners 继承的监听器列表，格式："name:fd,name:fd"
+	envInheritedListeners = "LITECORE_INHERITED_LISTENERS"
+	// envRestartReadyFD 通知父进程“新进程已就绪”的管道文件描述符
+	envRestartReadyFD = "LITECORE_RESTART_READY_FD"
+
+	// listenerHTTP 主 HTTP 监听器名称
+	listenerHTTP = "http"
+	// listenerAdmin 管理端监听器名称
+	listenerAdmin = "admin"
+)
+
+// namedListener 命名监听器
+type namedListener struct {
+	name     string
+	listener net.Listener
+}
+
+// inheritedListeners 从父进程继承的监听器（仅在进程启动时解析一次）
+// 继承的文件描述符属于整个进程而非某个 Engine，按监听器名称只能被取出一次；
+// 同一进程内有多个 Engine 时，只有最先监听的 Engine 能继承父进程的套接字，平滑重启应只在一个 Engine 上启用
+var (
+	inheritedOnce sync.Once
+	inheritedMu   sync.Mutex
+	inheritedFDs  map[string]uintptr
+	inheritErr    error
+)
+
+// loadInheritedListeners 解析环境变量中继承的监听器文件描述符
+func loadInheritedListeners() (map[string]uintptr, error) {
+	inheritedOnce.Do(func() {
+		value := os.Getenv(envInheritedListeners)
+		_ = os.Unsetenv(envInheritedListeners)
+		inheritedFDs, inheritErr = parseInheritedListeners(value)
+	})
+	return inheritedFDs, inheritErr
+}
+
+// parseInheritedListeners 解析 "name:fd,name:fd" 格式的继承监听器列表
+func parseInheritedListeners(value string) (map[string]uintptr, error) {
+	result := make(map[string]uintptr)
+	if value == "" {
+		return result, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		name, fdStr, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid %s entry: %q", envInheritedListeners, item)
+		}
+		fd, err := strconv.ParseUint(fdStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s fd for %s: %w", envInheritedListeners, name, err)
+		}
+		result[name] = uintptr(fd)
+	}
+	return result, nil
+}
+
+// takeInheritedListener 取出指定名称的继承监听器，不存在时返回 nil
+func takeInheritedListener(name string) (net.Listener, error) {
+	fds, err := loadInheritedListeners()
+	if err != nil {
+		return nil, err
+	}
+
+	inheritedMu.Lock()
+	fd, ok := fds[name]
+	delete(fds, name)
+	inheritedMu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	return listenerFromFD(name, fd)
+}
+
+// listenerFromFD 由文件描述符重建监听器
+func listenerFromFD(name string, fd uintptr) (net.Listener, error) {
+	file := os.NewFile(fd, name)
+	if file == nil {
+		return nil, fmt.Errorf("inherited listener %s has invalid fd %d", name, fd)
+	}
+	defer file.Close()
+
+	ln, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild inherited listener %s: %w", name, err)
+	}
+	return ln, nil
+}
+
+// listen 创建监听器：优先使用从父进程继承的同名监听器，否则新建
+// 返回值 inherited 表示监听器是否继承自父进程
+func listen(name, network, address string) (ln net.Listener, inherited bool, err error) {
+	ln, err = takeInheritedListener(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if ln != nil {
+		return ln, true, nil
+	}
+
+	ln, err = net.Listen(network, address)
+	if err != nil {
+		return nil, false, err
+	}
+	return ln, false, nil
+}
+
+// notifyParentReady 通知父进程新进程已就绪（仅在平滑重启产生的子进程中生效）
+func notifyParentReady() error {
+	value := os.Getenv(envRestartReadyFD)
+	if value == "" {
+		return nil
+	}
+	_ = os.Unsetenv(envRestartReadyFD)
+
+	fd, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envRestartReadyFD, err)
+	}
+
+	pipe := os.NewFile(uintptr(fd), "restart-ready")
+	if pipe == nil {
+		return fmt.Errorf("invalid %s fd %d", envRestartReadyFD, fd)
+	}
+	defer pipe.Close()
+
+	if _, err := pipe.Write([]byte{1}); err != nil {
+		return fmt.Errorf("failed to notify parent process: %w", err)
+	}
+	return nil
+}
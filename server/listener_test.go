@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+)
+
+// TestParseInheritedListeners 测试继承监听器环境变量解析
+func TestParseInheritedListeners(t *testing.T) {
+	t.Run("空值_返回空列表", func(t *testing.T) {
+		fds, err := parseInheritedListeners("")
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if len(fds) != 0 {
+			t.Errorf("期望空列表, 实际: %v", fds)
+		}
+	})
+
+	t.Run("多个监听器_正确解析", func(t *testing.T) {
+		fds, err := parseInheritedListeners("http:3, admin:4")
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if fds["http"] != 3 || fds["admin"] != 4 {
+			t.Errorf("解析结果不正确: %v", fds)
+		}
+	})
+
+	t.Run("格式错误_返回错误", func(t *testing.T) {
+		for _, value := range []string{"http", ":3", "http:abc"} {
+			if _, err := parseInheritedListeners(value); err == nil {
+				t.Errorf("期望 %q 解析失败", value)
+			}
+		}
+	})
+}
+
+// TestListenerFromFD 测试由文件描述符重建监听器（模拟新进程继承监听器）
+func TestListenerFromFD(t *testing.T) {
+	original, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	defer original.Close()
+
+	file, err := original.(fileListener).File()
+	if err != nil {
+		t.Fatalf("获取文件描述符失败: %v", err)
+	}
+
+	inherited, err := listenerFromFD(listenerHTTP, file.Fd())
+	if err != nil {
+		t.Fatalf("重建监听器失败: %v", err)
+	}
+	defer inherited.Close()
+
+	if inherited.Addr().String() != original.Addr().String() {
+		t.Errorf("期望地址 %s, 实际 %s", original.Addr(), inherited.Addr())
+	}
+
+	// 关闭原监听器后，继承的监听器仍可接受连接
+	_ = original.Close()
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})}
+	go func() { _ = srv.Serve(inherited) }()
+	defer srv.Close()
+
+	resp, err := http.Get("http://" + inherited.Addr().String())
+	if err != nil {
+		t.Fatalf("请求继承的监听器失败: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("期望 204, 实际: %d", resp.StatusCode)
+	}
+}
+
+// TestRestartEnviron 测试平滑重启时过滤遗留的继承变量
+func TestRestartEnviron(t *testing.T) {
+	env := restartEnviron([]string{
+		"PATH=/usr/bin",
+		envInheritedListeners + "=http:3",
+		envRestartReadyFD + "=4",
+		"APP_ENV=prod",
+	})
+
+	if len(env) != 2 || env[0] != "PATH=/usr/bin" || env[1] != "APP_ENV=prod" {
+		t.Errorf("过滤结果不正确: %v", env)
+	}
+}
+
+// TestExitedBeforeReadyError 测试新进程就绪前退出的错误信息
+func TestExitedBeforeReadyError(t *testing.T) {
+	if err := exitedBeforeReadyError(nil); err.Error() != "new process exited with status 0 before ready" {
+		t.Errorf("正常退出的错误信息不正确: %v", err)
+	}
+
+	exitErr := errors.New("exit status 3")
+	err := exitedBeforeReadyError(exitErr)
+	if !errors.Is(err, exitErr) || err.Error() != "new process exited before ready: exit status 3" {
+		t.Errorf("异常退出的错误未被包装: %v", err)
+	}
+}
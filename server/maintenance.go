@@ -0,0 +1,445 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/logger"
+	"github.com/lite-lake/litecore-go/manager/configmgr"
+)
+
+// 维护模式切换来源
+const (
+	MaintenanceSourceConfig = "config" // 配置文件（启动时或配置重载）
+	MaintenanceSourceAdmin  = "admin"  // 管理端点 /api/maintenance
+	MaintenanceSourceSignal = "signal" // 信号（SIGUSR1）
+	MaintenanceSourceAPI    = "api"    // 代码调用 Engine.SetMaintenance
+)
+
+// defaultMaintenanceMessage 维护模式默认响应消息
+const defaultMaintenanceMessage = "service under maintenance"
+
+// MaintenanceConfig 维护模式配置（对应 server.maintenance）
+type MaintenanceConfig struct {
+	Enabled        bool          `yaml:"enabled"`         // 启动时是否处于维护模式，默认关闭
+	Message        string        `yaml:"message"`         // 维护期间响应的错误消息
+	RetryAfter     time.Duration `yaml:"retry_after"`     // 响应的 Retry-After 头，0 表示不设置
+	AllowPaths     []string      `yaml:"allow_paths"`     // 维护期间仍放行的路径，以 * 结尾时按前缀匹配
+	AllowIPs       []string      `yaml:"allow_ips"`       // 维护期间仍放行的客户端 IP 或 CIDR
+	PauseWorkers   bool          `yaml:"pause_workers"`   // 维护期间是否暂停监听器消费和定时器执行，默认关闭
+	ReloadInterval time.Duration `yaml:"reload_interval"` // 配置文件变更检测间隔，变更后重新应用 server.maintenance，0 表示不检测
+	Signal         bool          `yaml:"signal"`          // 是否通过 SIGUSR1 切换维护模式，默认关闭（SIGUSR1 保持默认行为：终止进程）
+}
+
+// DefaultMaintenanceConfig 返回默认的维护模式配置
+func DefaultMaintenanceConfig() *MaintenanceConfig {
+	return &MaintenanceConfig{
+		Enabled: false,
+		Message: defaultMaintenanceMessage,
+	}
+}
+
+// Validate 验证维护模式配置
+func (c *MaintenanceConfig) Validate() error {
+	var errs []error
+	if c.RetryAfter < 0 {
+		errs = append(errs, fmt.Errorf("server.maintenance.retry_after: cannot be negative"))
+	}
+	if c.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("server.maintenance.reload_interval: cannot be negative"))
+	}
+	for _, path := range c.AllowPaths {
+		if !strings.HasPrefix(path, "/") {
+			errs = append(errs, fmt.Errorf("server.maintenance.allow_paths: %q must start with /", path))
+		}
+	}
+	if _, err := parseAllowIPs(c.AllowIPs); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// parseAllowIPs 将 IP 或 CIDR 解析为网段，单个 IP 视为只包含自身的网段
+func parseAllowIPs(entries []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			_, ipNet, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("server.maintenance.allow_ips: invalid CIDR %q", entry)
+			}
+			nets = append(nets, ipNet)
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, fmt.Errorf("server.maintenance.allow_ips: invalid IP %q", entry)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets, nil
+}
+
+// MaintenanceStatus 维护模式状态
+type MaintenanceStatus struct {
+	Enabled      bool      `json:"enabled"`        // 是否处于维护模式
+	Message      string    `json:"message"`        // 维护期间响应的错误消息
+	Since        time.Time `json:"since,omitzero"` // 进入维护模式的时间
+	Source       string    `json:"source"`         // 最近一次切换的来源
+	PauseWorkers bool      `json:"pause_workers"`  // 监听器和定时器是否随维护模式暂停
+}
+
+// maintenanceMode 维护模式运行时状态
+type maintenanceMode struct {
+	mu        sync.RWMutex
+	cfg       *MaintenanceConfig
+	allowNets []*net.IPNet
+	enabled   bool
+	message   string // 通过管理端点或 API 设置的消息，为空时使用配置中的消息
+	since     time.Time
+	source    string
+	resumeCh  chan struct{} // 工作者暂停期间未关闭，恢复时关闭
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newMaintenanceMode 按配置创建维护模式状态
+func newMaintenanceMode(cfg *MaintenanceConfig) *maintenanceMode {
+	m := &maintenanceMode{
+		resumeCh: make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+	close(m.resumeCh)
+	m.applyConfig(cfg)
+	return m
+}
+
+// applyConfig 应用配置：更新放行名单等设置，并按 enabled 切换维护模式
+func (m *maintenanceMode) applyConfig(cfg *MaintenanceConfig) bool {
+	if cfg == nil {
+		cfg = DefaultMaintenanceConfig()
+	}
+	allowNets, _ := parseAllowIPs(cfg.AllowIPs) // 已在 Validate 中校验
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cfg = cfg
+	m.allowNets = allowNets
+	return m.setLocked(cfg.Enabled, "", MaintenanceSourceConfig)
+}
+
+// set 切换维护模式，message 为空时使用配置中的消息；返回状态是否改变
+func (m *maintenanceMode) set(enabled bool, message, source string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.setLocked(enabled, message, source)
+}
+
+func (m *maintenanceMode) setLocked(enabled bool, message, source string) bool {
+	changed := m.enabled != enabled
+	if changed && enabled {
+		m.since = time.Now()
+	}
+	if !enabled {
+		m.since = time.Time{}
+	}
+	m.enabled = enabled
+	m.message = message
+	m.source = source
+
+	// 同步工作者暂停状态
+	paused := enabled && m.cfg.PauseWorkers
+	select {
+	case <-m.resumeCh:
+		if paused {
+			m.resumeCh = make(chan struct{})
+		}
+	default:
+		if !paused {
+			close(m.resumeCh)
+		}
+	}
+	return changed
+}
+
+// status 返回当前状态
+func (m *maintenanceMode) status() MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return MaintenanceStatus{
+		Enabled:      m.enabled,
+		Message:      m.messageLocked(),
+		Since:        m.since,
+		Source:       m.source,
+		PauseWorkers: m.cfg.PauseWorkers,
+	}
+}
+
+func (m *maintenanceMode) messageLocked() string {
+	if m.message != "" {
+		return m.message
+	}
+	if m.cfg.Message != "" {
+		return m.cfg.Message
+	}
+	return defaultMaintenanceMessage
+}
+
+// active 是否处于维护模式
+func (m *maintenanceMode) active() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled
+}
+
+// workersPaused 监听器和定时器是否暂停
+func (m *maintenanceMode) workersPaused() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled && m.cfg.PauseWorkers
+}
+
+// waitResume 工作者暂停期间阻塞，直到恢复或 ctx 结束
+func (m *maintenanceMode) waitResume(ctx context.Context) error {
+	m.mu.RLock()
+	resumeCh := m.resumeCh
+	m.mu.RUnlock()
+
+	select {
+	case <-resumeCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// allows 判断维护期间请求是否放行（路径或客户端 IP 在放行名单中）
+func (m *maintenanceMode) allows(path, clientIP string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, allowed := range m.cfg.AllowPaths {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == allowed {
+			return true
+		}
+	}
+	if ip := net.ParseIP(clientIP); ip != nil {
+		for _, ipNet := range m.allowNets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rejection 返回维护期间拒绝请求的错误和 Retry-After 秒数
+func (m *maintenanceMode) rejection() (*common.BusinessError, int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return common.NewBusinessError(common.CodeServiceUnavail, m.messageLocked()), int(m.cfg.RetryAfter.Seconds())
+}
+
+// stop 停止配置变更检测
+func (m *maintenanceMode) stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+}
+
+// maintenanceHandler 维护模式中间件：维护期间对非系统路由返回 503，放行名单中的路径和 IP 除外
+func (e *Engine) maintenanceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if e.maintenance == nil || !e.maintenance.active() ||
+			e.systemPaths[c.FullPath()] || e.maintenance.allows(c.Request.URL.Path, c.ClientIP()) {
+			c.Next()
+			return
+		}
+
+		bizErr, retryAfter := e.maintenance.rejection()
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+		}
+		_ = c.Error(bizErr)
+		c.Abort()
+	}
+}
+
+// SetMaintenance 切换维护模式（需在 Initialize 之后调用），message 为空时使用配置中的消息
+func (e *Engine) SetMaintenance(enabled bool, message string) error {
+	return e.setMaintenance(enabled, message, MaintenanceSourceAPI)
+}
+
+// Maintenance 返回维护模式状态
+func (e *Engine) Maintenance() MaintenanceStatus {
+	if e.maintenance == nil {
+		return MaintenanceStatus{}
+	}
+	return e.maintenance.status()
+}
+
+// setMaintenance 切换维护模式并记录日志
+func (e *Engine) setMaintenance(enabled bool, message, source string) error {
+	if e.maintenance == nil {
+		return fmt.Errorf("engine not initialized")
+	}
+	if e.maintenance.set(enabled, message, source) {
+		e.logMaintenanceChange(source)
+	}
+	return nil
+}
+
+// logMaintenanceChange 记录维护模式切换
+func (e *Engine) logMaintenanceChange(source string) {
+	status := e.maintenance.status()
+	if status.Enabled {
+		e.logger().Warn("Maintenance mode enabled", "source", source, "message", status.Message, "pause_workers", status.PauseWorkers)
+	} else {
+		e.logger().Info("Maintenance mode disabled", "source", source)
+	}
+}
+
+// toggleMaintenance 收到维护信号时切换维护模式
+func (e *Engine) toggleMaintenance() {
+	if e.maintenance == nil {
+		return
+	}
+	_ = e.setMaintenance(!e.maintenance.active(), "", MaintenanceSourceSignal)
+}
+
+// maintenanceRequest 管理端点的切换请求
+type maintenanceRequest struct {
+	Enabled *bool  `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// handleMaintenance 维护模式端点：返回当前状态
+func (e *Engine) handleMaintenance(c *gin.Context) {
+	c.JSON(common.HTTPStatusOK, e.Maintenance())
+}
+
+// handleSetMaintenance 维护模式端点：切换维护模式，请求体 {"enabled": true, "message": "..."}
+func (e *Engine) handleSetMaintenance(c *gin.Context) {
+	var req maintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if req.Enabled == nil {
+		_ = c.Error(common.NewBadRequest("enabled is required"))
+		return
+	}
+	if err := e.setMaintenance(*req.Enabled, req.Message, MaintenanceSourceAdmin); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(common.HTTPStatusOK, e.Maintenance())
+}
+
+// pausableScheduler 维护模式暂停工作者期间跳过 OnTick 的定时器
+type pausableScheduler struct {
+	common.IBaseScheduler
+	mode *maintenanceMode
+	log  func() logger.ILogger
+}
+
+// OnTick 暂停期间跳过本次执行
+func (s *pausableScheduler) OnTick(tickID int64) error {
+	if s.mode.workersPaused() {
+		s.log().Info("Scheduler tick skipped during maintenance", "scheduler", s.SchedulerName(), "tickID", tickID)
+		return nil
+	}
+	return s.IBaseScheduler.OnTick(tickID)
+}
+
+// maintenanceConfigFile 返回可重新读取的配置文件，配置不来自文件时返回空
+func (e *Engine) maintenanceConfigFile() string {
+	if e.configManager != nil || e.builtinConfig == nil {
+		return ""
+	}
+	return e.builtinConfig.FilePath
+}
+
+// reloadMaintenanceConfig 重新读取配置文件中的 server.maintenance 段并应用
+func (e *Engine) reloadMaintenanceConfig() error {
+	cfgMgr, err := configmgr.Build(e.builtinConfig.Driver, e.builtinConfig.FilePath)
+	if err != nil {
+		return err
+	}
+
+	cfg := DefaultMaintenanceConfig()
+	section, err := cfgMgr.Get("server.maintenance")
+	if err != nil && !configmgr.IsConfigKeyNotFound(err) {
+		return err
+	}
+	if err == nil && section != nil {
+		raw, ok := section.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid server.maintenance config: expected map, got %T", section)
+		}
+		if err := decodeConfigMap("server.maintenance", raw, cfg); err != nil {
+			return err
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if e.maintenance.applyConfig(cfg) {
+		e.logMaintenanceChange(MaintenanceSourceConfig)
+	}
+	return nil
+}
+
+// watchMaintenanceConfig 按 reload_interval 检测配置文件变更，变更后重新应用 server.maintenance
+// 重载失败时保留当前状态；重载结果覆盖通过管理端点、信号或 API 做出的切换
+func (e *Engine) watchMaintenanceConfig() {
+	interval := e.serverConfig.Maintenance.ReloadInterval
+	file := e.maintenanceConfigFile()
+	if interval <= 0 || file == "" {
+		return
+	}
+
+	var modTime time.Time
+	if info, err := os.Stat(file); err == nil {
+		modTime = info.ModTime()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.maintenance.stopCh:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(file)
+			if err != nil || info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+			if err := e.reloadMaintenanceConfig(); err != nil {
+				e.logger().Error("Failed to reload maintenance config, keeping current state", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+)
+
+// newMaintenanceTestEngine 创建启用维护模式处理的测试引擎（debug 模式，系统路由挂载在公共端口）
+func newMaintenanceTestEngine(t *testing.T, cfg *MaintenanceConfig) *Engine {
+	t.Helper()
+	engine := newRouteTestEngine(t)
+	engine.serverConfig.Mode = "debug"
+	engine.maintenance = newMaintenanceMode(cfg)
+	engine.installErrorHandling(engine.ginEngine)
+	engine.ginEngine.Use(engine.maintenanceHandler())
+	engine.registerSystemRoutes()
+	engine.ginEngine.GET("/api/orders", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	engine.ginEngine.GET("/api/webhooks/pay", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return engine
+}
+
+// TestMaintenanceHandler 测试维护模式拦截与放行
+func TestMaintenanceHandler(t *testing.T) {
+	cfg := DefaultMaintenanceConfig()
+	cfg.Message = "upgrading"
+	cfg.RetryAfter = 30 * time.Second
+	cfg.AllowPaths = []string{"/api/webhooks/*"}
+	cfg.AllowIPs = []string{"10.0.0.0/8", "192.168.1.5"}
+	engine := newMaintenanceTestEngine(t, cfg)
+
+	serve := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if remoteAddr != "" {
+			req.RemoteAddr = remoteAddr
+		}
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("未启用_正常响应", func(t *testing.T) {
+		if w := serve("/api/orders", ""); w.Code != http.StatusOK {
+			t.Errorf("期望 200, 实际 %d", w.Code)
+		}
+	})
+
+	if err := engine.SetMaintenance(true, ""); err != nil {
+		t.Fatalf("切换维护模式失败: %v", err)
+	}
+
+	t.Run("启用_业务路由返回503", func(t *testing.T) {
+		w := serve("/api/orders", "")
+		assertAPIError(t, w, http.StatusServiceUnavailable, common.CodeServiceUnavail)
+		if !strings.Contains(w.Body.String(), "upgrading") {
+			t.Errorf("期望配置的消息, 实际 %s", w.Body.String())
+		}
+		if w.Header().Get("Retry-After") != "30" {
+			t.Errorf("期望 Retry-After=30, 实际 %q", w.Header().Get("Retry-After"))
+		}
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		want       int
+	}{
+		{name: "系统路由不拦截", path: "/api/health", want: http.StatusOK},
+		{name: "放行路径前缀", path: "/api/webhooks/pay", want: http.StatusOK},
+		{name: "放行网段", path: "/api/orders", remoteAddr: "10.1.2.3:5000", want: http.StatusOK},
+		{name: "放行单个IP", path: "/api/orders", remoteAddr: "192.168.1.5:5000", want: http.StatusOK},
+		{name: "非放行IP", path: "/api/orders", remoteAddr: "192.168.1.6:5000", want: http.StatusServiceUnavailable},
+		{name: "未匹配路由", path: "/api/unknown", want: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(tt.path, tt.remoteAddr); w.Code != tt.want {
+				t.Errorf("期望 %d, 实际 %d", tt.want, w.Code)
+			}
+		})
+	}
+
+	t.Run("关闭_恢复正常", func(t *testing.T) {
+		_ = engine.SetMaintenance(false, "")
+		if w := serve("/api/orders", ""); w.Code != http.StatusOK {
+			t.Errorf("期望 200, 实际 %d", w.Code)
+		}
+	})
+}
+
+// TestMaintenanceConfig_Validate 测试维护模式配置校验
+func TestMaintenanceConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *MaintenanceConfig)
+		wantErr string
+	}{
+		{name: "默认配置", modify: func(c *MaintenanceConfig) {}},
+		{name: "合法放行名单", modify: func(c *MaintenanceConfig) {
+			c.AllowPaths = []string{"/api/status", "/api/webhooks/*"}
+			c.AllowIPs = []string{"127.0.0.1", "::1", "10.0.0.0/8"}
+		}},
+		{name: "非法IP", modify: func(c *MaintenanceConfig) { c.AllowIPs = []string{"10.0.0.300"} }, wantErr: "invalid IP"},
+		{name: "非法CIDR", modify: func(c *MaintenanceConfig) { c.AllowIPs = []string{"10.0.0.0/40"} }, wantErr: "invalid CIDR"},
+		{name: "路径不以斜杠开头", modify: func(c *MaintenanceConfig) { c.AllowPaths = []string{"api"} }, wantErr: "must start with /"},
+		{name: "负数重试时间", modify: func(c *MaintenanceConfig) { c.RetryAfter = -time.Second }, wantErr: "retry_after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultMaintenanceConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("未期望的错误: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("期望错误包含 %q, 实际 %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestMaintenanceEndpoint 测试维护模式管理端点
+func TestMaintenanceEndpoint(t *testing.T) {
+	engine := newRouteTestEngine(t)
+	engine.serverConfig.Admin.Enabled = true
+	engine.initAdminEngine()
+	engine.maintenance = newMaintenanceMode(nil)
+	engine.registerSystemRoutes()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.adminEngine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/maintenance", bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := post(`{"enabled": true, "message": "db migration"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("期望 200, 实际 %d %s", w.Code, w.Body.String())
+	}
+	var status MaintenanceStatus
+	_ = json.Unmarshal(w.Body.Bytes(), &status)
+	if !status.Enabled || status.Message != "db migration" || status.Source != MaintenanceSourceAdmin || status.Since.IsZero() {
+		t.Errorf("状态不正确: %+v", status)
+	}
+
+	if code := serveStatus(engine.adminEngine, "/api/maintenance"); code != http.StatusOK {
+		t.Errorf("维护期间端点应可访问, 实际 %d", code)
+	}
+
+	assertAPIError(t, post(`{"message": "x"}`), http.StatusBadRequest, common.CodeBadRequest)
+
+	if w := post(`{"enabled": false}`); w.Code != http.StatusOK || engine.Maintenance().Enabled {
+		t.Errorf("关闭维护模式失败: %d %+v", w.Code, engine.Maintenance())
+	}
+}
+
+// TestMaintenanceEndpoint_DebugPublic 测试 debug 模式未启用管理端时公共端口不可切换维护模式
+func TestMaintenanceEndpoint_DebugPublic(t *testing.T) {
+	engine := newMaintenanceTestEngine(t, nil)
+
+	w := httptest.NewRecorder()
+	engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/maintenance", bytes.NewBufferString(`{"enabled": true}`)))
+	if w.Code == http.StatusOK || engine.Maintenance().Enabled {
+		t.Errorf("公共端口不应挂载 POST /api/maintenance, 实际 %d %+v", w.Code, engine.Maintenance())
+	}
+	if code := serveStatus(engine.ginEngine, "/api/maintenance"); code != http.StatusOK {
+		t.Errorf("debug 模式下 GET /api/maintenance 期望 200, 实际 %d", code)
+	}
+}
+
+// TestMaintenanceReadiness 测试维护模式下的就绪状态
+func TestMaintenanceReadiness(t *testing.T) {
+	engine := newMaintenanceTestEngine(t, nil)
+	engine.readiness = newTestReadinessChecker(nil)
+	engine.readiness.add(HealthCheck{Name: "db", Critical: true, Check: healthyCheck})
+	engine.readiness.refresh(context.Background())
+	engine.ready.Store(true)
+
+	_ = engine.SetMaintenance(true, "")
+	if report := engine.Readiness(); report.Status != ReadinessMaintenance {
+		t.Errorf("期望 maintenance, 实际 %s", report.Status)
+	}
+	if code := serveStatus(engine.ginEngine, "/api/ready"); code != http.StatusServiceUnavailable {
+		t.Errorf("期望 503, 实际 %d", code)
+	}
+
+	_ = engine.SetMaintenance(false, "")
+	if report := engine.Readiness(); report.Status != ReadinessOK {
+		t.Errorf("期望 ok, 实际 %s", report.Status)
+	}
+}
+
+// testTickScheduler 记录 OnTick 次数的测试定时器
+type testTickScheduler struct {
+	ticks atomic.Int32
+}
+
+func (s *testTickScheduler) SchedulerName() string     { return "TickScheduler" }
+func (s *testTickScheduler) GetRule() string           { return "0 * * * * *" }
+func (s *testTickScheduler) GetTimezone() string       { return "" }
+func (s *testTickScheduler) OnTick(tickID int64) error { s.ticks.Add(1); return nil }
+func (s *testTickScheduler) OnStart() error            { return nil }
+func (s *testTickScheduler) OnStop() error             { return nil }
+
+// TestMaintenancePauseWorkers 测试维护模式暂停监听器和定时器
+func TestMaintenancePauseWorkers(t *testing.T) {
+	cfg := DefaultMaintenanceConfig()
+	cfg.PauseWorkers = true
+	engine := newMaintenanceTestEngine(t, cfg)
+	mode := engine.maintenance
+
+	scheduler := &testTickScheduler{}
+	wrapped := &pausableScheduler{IBaseScheduler: scheduler, mode: mode, log: engine.logger}
+
+	_ = engine.SetMaintenance(true, "")
+	_ = wrapped.OnTick(1)
+	if scheduler.ticks.Load() != 0 {
+		t.Error("暂停期间不应执行 OnTick")
+	}
+
+	resumed := make(chan error, 1)
+	go func() { resumed <- mode.waitResume(context.Background()) }()
+	select {
+	case <-resumed:
+		t.Fatal("暂停期间 waitResume 不应返回")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	_ = engine.SetMaintenance(false, "")
+	select {
+	case err := <-resumed:
+		if err != nil {
+			t.Errorf("恢复后不应返回错误: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("恢复后 waitResume 未返回")
+	}
+	_ = wrapped.OnTick(2)
+	if scheduler.ticks.Load() != 1 {
+		t.Error("恢复后应执行 OnTick")
+	}
+
+	t.Run("未启用暂停_不阻塞", func(t *testing.T) {
+		mode := newMaintenanceMode(DefaultMaintenanceConfig())
+		mode.set(true, "", MaintenanceSourceAPI)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := mode.waitResume(ctx); err != nil {
+			t.Errorf("未启用暂停时不应阻塞: %v", err)
+		}
+	})
+}
+
+// TestReloadMaintenanceConfig 测试从配置文件重新加载维护模式
+func TestReloadMaintenanceConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engine := newRouteTestEngine(t)
+	engine.builtinConfig = &BuiltinConfig{Driver: "yaml", FilePath: path}
+	engine.maintenance = newMaintenanceMode(nil)
+
+	write("server:\n  maintenance:\n    enabled: true\n    message: scheduled\n")
+	if err := engine.reloadMaintenanceConfig(); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if status := engine.Maintenance(); !status.Enabled || status.Message != "scheduled" || status.Source != MaintenanceSourceConfig {
+		t.Errorf("状态不正确: %+v", status)
+	}
+
+	write("server:\n  maintenance:\n    enabled: true\n    unknown: 1\n")
+	if err := engine.reloadMaintenanceConfig(); err == nil || !strings.Contains(err.Error(), "server.maintenance.unknown") {
+		t.Errorf("期望未知键错误, 实际 %v", err)
+	}
+	if !engine.Maintenance().Enabled {
+		t.Error("重新加载失败时应保留当前状态")
+	}
+
+	write("server:\n  port: 8080\n")
+	if err := engine.reloadMaintenanceConfig(); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if engine.Maintenance().Enabled {
+		t.Error("删除 maintenance 段后应退出维护模式")
+	}
+}
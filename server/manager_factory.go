@@ -0,0 +1,252 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/manager/configmgr"
+)
+
+// ManagerFactory 管理器工厂，描述如何构建接口类型对应的管理器及其依赖的其他管理器
+type ManagerFactory struct {
+	ifaceType reflect.Type
+	dependsOn []reflect.Type
+	section   string // 配置段名称，为空时总是构建
+	build     func(managers *container.ManagerContainer) (common.IBaseManager, error)
+}
+
+// NewManagerFactory 创建接口 T 的管理器工厂
+// build 在 dependsOn 中声明的管理器全部构建完成后调用，可通过 container.GetManager 获取它们；
+// 配置管理器总是可用，无需声明
+func NewManagerFactory[T common.IBaseManager](
+	build func(managers *container.ManagerContainer) (T, error),
+	dependsOn ...reflect.Type,
+) *ManagerFactory {
+	return &ManagerFactory{
+		ifaceType: reflect.TypeOf((*T)(nil)).Elem(),
+		dependsOn: dependsOn,
+		build: func(managers *container.ManagerContainer) (common.IBaseManager, error) {
+			return build(managers)
+		},
+	}
+}
+
+// ManagerType 返回管理器接口 T 的类型，用于声明工厂依赖
+func ManagerType[T common.IBaseManager]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// InterfaceType 返回工厂构建的管理器接口类型
+func (f *ManagerFactory) InterfaceType() reflect.Type {
+	return f.ifaceType
+}
+
+// DependsOn 返回工厂声明的依赖管理器接口类型
+func (f *ManagerFactory) DependsOn() []reflect.Type {
+	return f.dependsOn
+}
+
+// WithConfigSection 设置管理器对应的配置段，使其可通过配置禁用或延迟构建：
+//   - <section>.enabled 为 false 时不构建，注入该管理器时返回错误
+//   - <section>.lazy 为 true 时仅在被注入或被其他管理器依赖时构建（默认取 server.lazy_managers）
+func (f *ManagerFactory) WithConfigSection(section string) *ManagerFactory {
+	f.section = section
+	return f
+}
+
+// managerDisplayName 返回管理器接口的展示名称（去掉接口前缀 I）
+func managerDisplayName(ifaceType reflect.Type) string {
+	return strings.TrimPrefix(ifaceType.Name(), "I")
+}
+
+// sortManagerFactories 按依赖顺序排列工厂，依赖相同时保持注册顺序
+// registered 为已注册到容器中的管理器（如配置管理器），可作为依赖但不参与排序
+func sortManagerFactories(factories []*ManagerFactory, registered func(reflect.Type) bool) ([]*ManagerFactory, error) {
+	byType := make(map[reflect.Type]*ManagerFactory, len(factories))
+	for _, factory := range factories {
+		if factory == nil || factory.build == nil {
+			return nil, fmt.Errorf("manager factory cannot be nil")
+		}
+		if _, exists := byType[factory.ifaceType]; exists || registered(factory.ifaceType) {
+			return nil, fmt.Errorf("manager %v registered more than once", factory.ifaceType)
+		}
+		byType[factory.ifaceType] = factory
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[reflect.Type]int, len(factories))
+	sorted := make([]*ManagerFactory, 0, len(factories))
+	var path []string
+
+	var visit func(factory *ManagerFactory) error
+	visit = func(factory *ManagerFactory) error {
+		switch state[factory.ifaceType] {
+		case visited:
+			return nil
+		case visiting:
+			return &container.CircularDependencyError{Cycle: cycleFrom(path, factory.ifaceType.String())}
+		}
+
+		state[factory.ifaceType] = visiting
+		path = append(path, factory.ifaceType.String())
+		for _, dep := range factory.dependsOn {
+			if depFactory, ok := byType[dep]; ok {
+				if err := visit(depFactory); err != nil {
+					return err
+				}
+				continue
+			}
+			if !registered(dep) {
+				return &container.DependencyNotFoundError{
+					InstanceName:  managerDisplayName(factory.ifaceType),
+					FieldType:     dep,
+					ContainerType: "Manager",
+					Message:       "no manager factory registered",
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[factory.ifaceType] = visited
+		sorted = append(sorted, factory)
+		return nil
+	}
+
+	for _, factory := range factories {
+		if err := visit(factory); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// cycleFrom 截取 path 中从 node 开始的循环部分
+func cycleFrom(path []string, node string) []string {
+	for i, name := range path {
+		if name == node {
+			return append([]string(nil), path[i:]...)
+		}
+	}
+	return []string{node}
+}
+
+// buildManagers 按依赖顺序构建工厂中的管理器并注册到容器中
+// required 判断管理器是否被组件需要，为 nil 时忽略 lazy 设置构建所有启用的管理器
+// onBuilt 在每个管理器构建完成后以名称和耗时回调，可为 nil
+func buildManagers(
+	cntr *container.ManagerContainer,
+	factories []*ManagerFactory,
+	required func(reflect.Type) bool,
+	logf func(msg string),
+	onBuilt func(name string, d time.Duration),
+) error {
+	sorted, err := sortManagerFactories(factories, func(ifaceType reflect.Type) bool {
+		return cntr.GetByType(ifaceType) != nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve manager dependencies: %w", err)
+	}
+
+	configMgr, err := container.GetManager[configmgr.IConfigManager](cntr)
+	if err != nil {
+		return err
+	}
+	lazyDefault, err := configBool(configMgr, "server.lazy_managers", false)
+	if err != nil {
+		return err
+	}
+
+	// 确定需要构建的管理器：启用且非延迟的，或被组件需要的
+	disabled := make(map[reflect.Type]string)
+	needed := make(map[reflect.Type]bool)
+	for _, factory := range sorted {
+		if factory.section == "" {
+			needed[factory.ifaceType] = true
+			continue
+		}
+		enabled, err := configBool(configMgr, factory.section+".enabled", true)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			reason := factory.section + ".enabled is false"
+			if required != nil && required(factory.ifaceType) {
+				return fmt.Errorf("%s is required by registered components but disabled (%s)",
+					managerDisplayName(factory.ifaceType), reason)
+			}
+			disabled[factory.ifaceType] = reason
+			cntr.MarkDisabled(factory.ifaceType, reason)
+			logf("Disabled: " + managerDisplayName(factory.ifaceType))
+			continue
+		}
+		lazy, err := configBool(configMgr, factory.section+".lazy", lazyDefault)
+		if err != nil {
+			return err
+		}
+		if !lazy || required == nil || required(factory.ifaceType) {
+			needed[factory.ifaceType] = true
+		}
+	}
+
+	// 需要构建的管理器所依赖的管理器同样需要构建（逆序遍历保证依赖关系传递）
+	for i := len(sorted) - 1; i >= 0; i-- {
+		factory := sorted[i]
+		if !needed[factory.ifaceType] {
+			continue
+		}
+		for _, dep := range factory.dependsOn {
+			if reason, ok := disabled[dep]; ok {
+				return fmt.Errorf("%s depends on disabled %s (%s)",
+					managerDisplayName(factory.ifaceType), managerDisplayName(dep), reason)
+			}
+			needed[dep] = true
+		}
+	}
+
+	for _, factory := range sorted {
+		name := managerDisplayName(factory.ifaceType)
+		if _, ok := disabled[factory.ifaceType]; ok {
+			continue
+		}
+		if !needed[factory.ifaceType] {
+			logf("Skipped (lazy, not injected): " + name)
+			continue
+		}
+		start := time.Now()
+		mgr, err := factory.build(cntr)
+		if onBuilt != nil {
+			onBuilt(name, time.Since(start))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", name, err)
+		}
+		if mgr == nil {
+			return fmt.Errorf("failed to create %s: factory returned nil", name)
+		}
+		if err := cntr.RegisterByType(factory.ifaceType, mgr); err != nil {
+			return fmt.Errorf("failed to register %s: %w", name, err)
+		}
+		logf("Initialization complete: " + name)
+	}
+	return nil
+}
+
+// configBool 读取布尔配置项，不存在时返回默认值，类型错误时返回错误
+func configBool(cfg configmgr.IConfigManager, key string, defaultValue bool) (bool, error) {
+	value, err := configmgr.Get[bool](cfg, key)
+	if err != nil {
+		if errors.Is(err, configmgr.ErrKeyNotFound) {
+			return defaultValue, nil
+		}
+		return false, fmt.Errorf("invalid config %s: %w", key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,364 @@
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/manager/cachemgr"
+	"github.com/lite-lake/litecore-go/manager/configmgr"
+	"github.com/lite-lake/litecore-go/manager/notificationmgr"
+)
+
+type iTestStorageManager interface {
+	common.IBaseManager
+	Bucket() string
+}
+
+type iTestSearchManager interface {
+	common.IBaseManager
+}
+
+type testStorageManager struct {
+	bucket string
+	cache  cachemgr.ICacheManager
+}
+
+func (m *testStorageManager) ManagerName() string { return "TestStorageManager" }
+func (m *testStorageManager) Health() error       { return nil }
+func (m *testStorageManager) OnStart() error      { return nil }
+func (m *testStorageManager) OnStop() error       { return nil }
+func (m *testStorageManager) Bucket() string      { return m.bucket }
+
+type testSearchManager struct{}
+
+func (m *testSearchManager) ManagerName() string { return "TestSearchManager" }
+func (m *testSearchManager) Health() error       { return nil }
+func (m *testSearchManager) OnStart() error      { return nil }
+func (m *testSearchManager) OnStop() error       { return nil }
+
+func newTestStorageFactory(dependsOn ...reflect.Type) *ManagerFactory {
+	return NewManagerFactory(func(m *container.ManagerContainer) (iTestStorageManager, error) {
+		return &testStorageManager{}, nil
+	}, dependsOn...)
+}
+
+func newTestSearchFactory(dependsOn ...reflect.Type) *ManagerFactory {
+	return NewManagerFactory(func(m *container.ManagerContainer) (iTestSearchManager, error) {
+		return &testSearchManager{}, nil
+	}, dependsOn...)
+}
+
+// TestSortManagerFactories 测试管理器工厂的依赖排序
+func TestSortManagerFactories(t *testing.T) {
+	configType := ManagerType[configmgr.IConfigManager]()
+	registered := func(ifaceType reflect.Type) bool { return ifaceType == configType }
+
+	t.Run("依赖先于被依赖者构建", func(t *testing.T) {
+		search := newTestSearchFactory(ManagerType[iTestStorageManager](), configType)
+		storage := newTestStorageFactory()
+
+		sorted, err := sortManagerFactories([]*ManagerFactory{search, storage}, registered)
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if len(sorted) != 2 || sorted[0] != storage || sorted[1] != search {
+			t.Errorf("排序结果不正确: %v", sorted)
+		}
+	})
+
+	t.Run("依赖缺失_返回错误", func(t *testing.T) {
+		_, err := sortManagerFactories([]*ManagerFactory{
+			newTestSearchFactory(ManagerType[iTestStorageManager]()),
+		}, registered)
+		var notFound *container.DependencyNotFoundError
+		if !errors.As(err, &notFound) || notFound.FieldType != ManagerType[iTestStorageManager]() {
+			t.Errorf("期望 DependencyNotFoundError, 实际 %v", err)
+		}
+	})
+
+	t.Run("循环依赖_返回错误", func(t *testing.T) {
+		_, err := sortManagerFactories([]*ManagerFactory{
+			newTestSearchFactory(ManagerType[iTestStorageManager]()),
+			newTestStorageFactory(ManagerType[iTestSearchManager]()),
+		}, registered)
+		var cycle *container.CircularDependencyError
+		if !errors.As(err, &cycle) || len(cycle.Cycle) != 2 {
+			t.Errorf("期望包含两个节点的 CircularDependencyError, 实际 %v", err)
+		}
+	})
+
+	t.Run("重复注册_返回错误", func(t *testing.T) {
+		if _, err := sortManagerFactories([]*ManagerFactory{
+			newTestStorageFactory(), newTestStorageFactory(),
+		}, registered); err == nil {
+			t.Error("重复注册同一接口期望返回错误")
+		}
+		if _, err := sortManagerFactories([]*ManagerFactory{
+			NewManagerFactory(func(m *container.ManagerContainer) (configmgr.IConfigManager, error) {
+				return nil, nil
+			}),
+		}, registered); err == nil {
+			t.Error("与已注册管理器重复期望返回错误")
+		}
+	})
+}
+
+// TestInitializeWithManagerFactories 测试自定义管理器与内置管理器一起构建
+func TestInitializeWithManagerFactories(t *testing.T) {
+	configMgr := configmgr.NewMemoryConfigManager(map[string]any{
+		"telemetry": map[string]any{"driver": "none"},
+		"logger":    map[string]any{"driver": "none"},
+		"database":  map[string]any{"driver": "none"},
+		"cache": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"lock": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"limiter": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"mq": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"scheduler": map[string]any{
+			"driver":      "cron",
+			"cron_config": map[string]any{},
+		},
+		"storage": map[string]any{"bucket": "uploads"},
+	})
+
+	storage := NewManagerFactory(func(m *container.ManagerContainer) (iTestStorageManager, error) {
+		cfg, err := container.GetManager[configmgr.IConfigManager](m)
+		if err != nil {
+			return nil, err
+		}
+		bucket, err := configmgr.Get[string](cfg, "storage.bucket")
+		if err != nil {
+			return nil, err
+		}
+		cache, err := container.GetManager[cachemgr.ICacheManager](m)
+		if err != nil {
+			return nil, err
+		}
+		return &testStorageManager{bucket: bucket, cache: cache}, nil
+	}, ManagerType[cachemgr.ICacheManager]())
+
+	cntr, err := InitializeWithConfigManager(configMgr, storage)
+	if err != nil {
+		t.Fatalf("未期望的错误: %v", err)
+	}
+	t.Cleanup(func() {
+		for _, mgr := range cntr.GetAll() {
+			_ = mgr.OnStop()
+		}
+	})
+
+	mgr, err := container.GetManager[iTestStorageManager](cntr)
+	if err != nil {
+		t.Fatalf("自定义管理器未注册: %v", err)
+	}
+	if mgr.Bucket() != "uploads" || mgr.(*testStorageManager).cache == nil {
+		t.Errorf("自定义管理器构建不正确: %+v", mgr)
+	}
+	if cntr.Count() != 11 {
+		t.Errorf("期望 11 个管理器, 实际 %d", cntr.Count())
+	}
+
+	t.Run("工厂返回错误_初始化失败", func(t *testing.T) {
+		failing := NewManagerFactory(func(m *container.ManagerContainer) (iTestSearchManager, error) {
+			return nil, errors.New("connection refused")
+		})
+		if _, err := InitializeWithConfigManager(configMgr, failing); err == nil {
+			t.Error("工厂返回错误时期望初始化失败")
+		}
+	})
+}
+
+// newFactoryTestContainer 创建仅注册了配置管理器的管理器容器
+func newFactoryTestContainer(t *testing.T, cfg map[string]any) *container.ManagerContainer {
+	t.Helper()
+	cntr := container.NewManagerContainer()
+	if err := container.RegisterManager[configmgr.IConfigManager](cntr, configmgr.NewMemoryConfigManager(cfg)); err != nil {
+		t.Fatalf("注册配置管理器失败: %v", err)
+	}
+	return cntr
+}
+
+// TestBuildManagersEnabledAndLazy 测试按配置禁用和延迟构建管理器
+func TestBuildManagersEnabledAndLazy(t *testing.T) {
+	storageType := ManagerType[iTestStorageManager]()
+	searchType := ManagerType[iTestSearchManager]()
+	noop := func(string) {}
+	factories := func() []*ManagerFactory {
+		return []*ManagerFactory{
+			newTestStorageFactory().WithConfigSection("storage"),
+			newTestSearchFactory(storageType).WithConfigSection("search"),
+		}
+	}
+
+	t.Run("禁用_不构建并记录原因", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"search": map[string]any{"enabled": false},
+		})
+		if err := buildManagers(cntr, factories(), nil, noop, nil); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cntr.GetByType(searchType) != nil || cntr.GetByType(storageType) == nil {
+			t.Error("期望仅构建 StorageManager")
+		}
+		if reason, ok := cntr.DisabledReason(searchType); !ok || reason != "search.enabled is false" {
+			t.Errorf("禁用原因不正确: %q", reason)
+		}
+	})
+
+	t.Run("依赖被禁用的管理器_返回错误", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"storage": map[string]any{"enabled": false},
+		})
+		if err := buildManagers(cntr, factories(), nil, noop, nil); err == nil {
+			t.Error("依赖被禁用的管理器时期望返回错误")
+		}
+	})
+
+	t.Run("被组件需要但已禁用_返回错误", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"search": map[string]any{"enabled": false},
+		})
+		required := func(ifaceType reflect.Type) bool { return ifaceType == searchType }
+		if err := buildManagers(cntr, factories(), required, noop, nil); err == nil {
+			t.Error("被需要的管理器被禁用时期望返回错误")
+		}
+	})
+
+	t.Run("延迟构建_未被需要时跳过", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"server": map[string]any{"lazy_managers": true},
+		})
+		required := func(reflect.Type) bool { return false }
+		if err := buildManagers(cntr, factories(), required, noop, nil); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cntr.Count() != 1 {
+			t.Errorf("期望仅有配置管理器, 实际 %v", cntr.GetNames())
+		}
+	})
+
+	t.Run("延迟构建_被需要时连同依赖一起构建", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"storage": map[string]any{"lazy": true},
+			"search":  map[string]any{"lazy": true},
+		})
+		required := func(ifaceType reflect.Type) bool { return ifaceType == searchType }
+		if err := buildManagers(cntr, factories(), required, noop, nil); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cntr.GetByType(searchType) == nil || cntr.GetByType(storageType) == nil {
+			t.Errorf("期望构建 SearchManager 及其依赖, 实际 %v", cntr.GetNames())
+		}
+	})
+
+	t.Run("未提供需求信息_忽略延迟设置", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"server": map[string]any{"lazy_managers": true},
+		})
+		if err := buildManagers(cntr, factories(), nil, noop, nil); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cntr.Count() != 3 {
+			t.Errorf("期望构建全部管理器, 实际 %v", cntr.GetNames())
+		}
+	})
+
+	t.Run("开关类型错误_返回错误", func(t *testing.T) {
+		cntr := newFactoryTestContainer(t, map[string]any{
+			"storage": map[string]any{"enabled": []any{"yes"}},
+		})
+		if err := buildManagers(cntr, factories(), nil, noop, nil); err == nil {
+			t.Error("enabled 类型错误时期望返回错误")
+		}
+	})
+}
+
+type iTestCacheController interface {
+	common.IBaseController
+}
+
+type testCacheController struct {
+	testPlainController
+	CacheMgr cachemgr.ICacheManager `inject:""`
+}
+
+// TestEngineRequiredManagerTypes 测试根据组件注入需求确定需要构建的管理器
+func TestEngineRequiredManagerTypes(t *testing.T) {
+	engine := newOptionTestEngine()
+	if err := container.RegisterController[iTestCacheController](engine.Controller, &testCacheController{}); err != nil {
+		t.Fatalf("注册控制器失败: %v", err)
+	}
+
+	required := engine.requiredManagerTypes()
+	if len(required) != 2 || !required[ManagerType[cachemgr.ICacheManager]()] ||
+		!required[ManagerType[notificationmgr.INotificationManager]()] {
+		t.Errorf("期望仅需要 CacheManager 和引擎使用的 NotificationManager, 实际 %v", required)
+	}
+}
+
+type iTestCacheService interface {
+	common.IBaseService
+}
+
+// TestEngineRequiredManagerTypes_Constructor 测试构造函数参数依赖的管理器同样需要构建
+func TestEngineRequiredManagerTypes_Constructor(t *testing.T) {
+	engine := newOptionTestEngine()
+	err := container.RegisterServiceConstructor[iTestCacheService](engine.Service, func(cache cachemgr.ICacheManager) iTestCacheService {
+		return &testOrderService{}
+	})
+	if err != nil {
+		t.Fatalf("注册构造函数失败: %v", err)
+	}
+
+	required := engine.requiredManagerTypes()
+	if len(required) != 2 || !required[ManagerType[cachemgr.ICacheManager]()] {
+		t.Errorf("期望需要 CacheManager, 实际 %v", required)
+	}
+}
+
+// TestEngineLazyManagers_Notification 测试延迟构建时仍构建引擎发送事件所需的通知管理器
+func TestEngineLazyManagers_Notification(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification map[string]any
+		wantBuilt    bool
+	}{
+		{name: "未关闭通知_构建通知管理器", notification: map[string]any{"url": "http://127.0.0.1:1/hook"}, wantBuilt: true},
+		{name: "显式关闭通知_跳过且不报错", notification: map[string]any{"enabled": false}, wantBuilt: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newOptionTestEngine(WithConfigMap(map[string]any{
+				"server":       map[string]any{"mode": "test", "lazy_managers": true, "startup_log": map[string]any{"enabled": false}},
+				"telemetry":    map[string]any{"driver": "none"},
+				"logger":       map[string]any{"driver": "none"},
+				"notification": tt.notification,
+			}))
+			if err := engine.Initialize(); err != nil {
+				t.Fatalf("初始化失败: %v", err)
+			}
+			built := engine.Manager.GetByType(ManagerType[notificationmgr.INotificationManager]()) != nil
+			if built != tt.wantBuilt {
+				t.Errorf("期望构建通知管理器 = %v, 实际 = %v (%v)", tt.wantBuilt, built, engine.Manager.GetNames())
+			}
+			if engine.Manager.GetByType(ManagerType[cachemgr.ICacheManager]()) != nil {
+				t.Error("未被需要的 CacheManager 期望延迟跳过")
+			}
+		})
+	}
+}
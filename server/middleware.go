@@ -1,21 +1,54 @@
 package server
 
 import (
+	"path"
 	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
 
 	"github.com/lite-lake/litecore-go/common"
 	"github.com/lite-lake/litecore-go/logger"
 )
 
+// scopedMiddleware 限定作用范围的中间件及其包装函数
+type scopedMiddleware struct {
+	name    string
+	scope   common.MiddlewareScope
+	handler gin.HandlerFunc
+}
+
 // registerMiddlewares 注册中间件
+// 未声明作用范围的中间件通过 Use 全局注册；实现 IScopedMiddleware 的中间件在注册路由时按分组/路径挂载
 func (e *Engine) registerMiddlewares() error {
 	middlewares := e.Middleware.GetAll()
 
 	sortedMiddlewares := sortMiddlewares(middlewares)
 	registeredCount := 0
+	e.globalMiddlewares = nil
+	e.scopedMiddlewares = nil
 
 	for _, mw := range sortedMiddlewares {
+		if scoped, ok := mw.(common.IScopedMiddleware); ok {
+			scope := scoped.MiddlewareScope()
+			if !scope.IsGlobal() {
+				e.scopedMiddlewares = append(e.scopedMiddlewares, &scopedMiddleware{
+					name:    mw.MiddlewareName(),
+					scope:   scope,
+					handler: mw.Wrapper(),
+				})
+				e.logStartup(PhaseRouter, "Registering middleware",
+					logger.F("middleware", mw.MiddlewareName()),
+					logger.F("type", "scoped"),
+					logger.F("groups", strings.Join(scope.Groups, ",")),
+					logger.F("paths", strings.Join(scope.Paths, ",")))
+				registeredCount++
+				continue
+			}
+		}
+
 		e.ginEngine.Use(mw.Wrapper())
+		e.globalMiddlewares = append(e.globalMiddlewares, mw.MiddlewareName())
 		e.logStartup(PhaseRouter, "Registering middleware",
 			logger.F("middleware", mw.MiddlewareName()),
 			logger.F("type", "global"))
@@ -28,6 +61,95 @@ func (e *Engine) registerMiddlewares() error {
 	return nil
 }
 
+// scopedMiddlewaresFor 返回作用于指定分组和完整路径的中间件（已按 Order 排序）
+func (e *Engine) scopedMiddlewaresFor(group, fullPath string) []*scopedMiddleware {
+	var matched []*scopedMiddleware
+	for _, mw := range e.scopedMiddlewares {
+		if mw.matches(group, fullPath) {
+			matched = append(matched, mw)
+		}
+	}
+	return matched
+}
+
+// 路由表中中间件名称的作用范围标记
+const (
+	middlewareMarkerGlobal = "global:"
+	middlewareMarkerScoped = "scoped:"
+)
+
+// routeMiddlewareNames 返回路由实际执行的中间件链（按执行顺序，带作用范围标记）
+// 全局中间件仅注册在公共端口，管理端路由只列出限定作用范围的中间件
+func (e *Engine) routeMiddlewareNames(onAdmin bool, scoped []*scopedMiddleware) []string {
+	var names []string
+	if !onAdmin {
+		for _, name := range e.globalMiddlewares {
+			names = append(names, middlewareMarkerGlobal+name)
+		}
+	}
+	for _, mw := range scoped {
+		names = append(names, middlewareMarkerScoped+mw.name)
+	}
+	return names
+}
+
+// matches 判断中间件是否作用于指定分组和路径
+func (m *scopedMiddleware) matches(group, fullPath string) bool {
+	if group != "" {
+		for _, g := range m.scope.Groups {
+			if normalizeGroup(g) == group {
+				return true
+			}
+		}
+	}
+	for _, pattern := range m.scope.Paths {
+		if matchPathPattern(pattern, fullPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPattern 判断路径是否匹配模式
+// 以 "/**" 结尾的模式按前缀匹配，其余使用 path.Match 语法
+func matchPathPattern(pattern, fullPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		if prefix == "" {
+			return true
+		}
+		return fullPath == prefix || strings.HasPrefix(fullPath, prefix+"/")
+	}
+	matched, err := path.Match(pattern, fullPath)
+	return err == nil && matched
+}
+
+// normalizeGroup 规范化路由分组前缀：以 "/" 开头且不以 "/" 结尾，根分组返回空字符串
+func normalizeGroup(group string) string {
+	group = strings.TrimSpace(group)
+	if group == "" || group == "/" {
+		return ""
+	}
+	if !strings.HasPrefix(group, "/") {
+		group = "/" + group
+	}
+	return strings.TrimRight(group, "/")
+}
+
+// joinRoutePath 拼接分组前缀与路由路径，保留路由路径末尾的 "/"
+func joinRoutePath(group, relativePath string) string {
+	if group == "" {
+		return relativePath
+	}
+	if relativePath == "" {
+		return group
+	}
+	joined := path.Join(group, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
 // sortMiddlewares 按 Order 排序中间件
 func sortMiddlewares(middlewares []common.IBaseMiddleware) []common.IBaseMiddleware {
 	sorted := make([]common.IBaseMiddleware, len(middlewares))
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/logger"
+)
+
+type testGroupController struct {
+	name  string
+	group string
+	route string
+}
+
+func (c *testGroupController) ControllerName() string { return c.name }
+func (c *testGroupController) GetRouter() string      { return c.route }
+func (c *testGroupController) RouteGroup() string     { return c.group }
+func (c *testGroupController) Handle(ctx *gin.Context) {
+	ctx.String(http.StatusOK, ctx.GetString("trace"))
+}
+
+type testPlainController struct{}
+
+func (c *testPlainController) ControllerName() string { return "PlainController" }
+func (c *testPlainController) GetRouter() string      { return "/api/public [GET]" }
+func (c *testPlainController) Handle(ctx *gin.Context) {
+	ctx.String(http.StatusOK, ctx.GetString("trace"))
+}
+
+type testScopedMiddleware struct {
+	name  string
+	order int
+	scope common.MiddlewareScope
+}
+
+func (m *testScopedMiddleware) MiddlewareName() string                  { return m.name }
+func (m *testScopedMiddleware) Order() int                              { return m.order }
+func (m *testScopedMiddleware) OnStart() error                          { return nil }
+func (m *testScopedMiddleware) OnStop() error                           { return nil }
+func (m *testScopedMiddleware) MiddlewareScope() common.MiddlewareScope { return m.scope }
+func (m *testScopedMiddleware) Wrapper() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("trace", c.GetString("trace")+m.name+";")
+		c.Next()
+	}
+}
+
+type iTestAdminController interface{ common.IBaseController }
+type iTestReportController interface{ common.IBaseController }
+type iTestPlainController interface{ common.IBaseController }
+type iTestGlobalMiddleware interface{ common.IBaseMiddleware }
+type iTestAuthMiddleware interface{ common.IBaseMiddleware }
+type iTestAuditMiddleware interface{ common.IBaseMiddleware }
+
+// newRouteTestEngine 创建仅用于路由注册测试的引擎
+func newRouteTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	entityContainer := container.NewEntityContainer()
+	repositoryContainer := container.NewRepositoryContainer(entityContainer)
+	serviceContainer := container.NewServiceContainer(repositoryContainer)
+	controllerContainer := container.NewControllerContainer(serviceContainer)
+	middlewareContainer := container.NewMiddlewareContainer(serviceContainer)
+
+	engine := NewEngine(nil, entityContainer, repositoryContainer, serviceContainer,
+		controllerContainer, middlewareContainer, nil, nil)
+	engine.setLogger(logger.NewDefaultLogger("Test"))
+	engine.startupLogConfig.Enabled = false
+	engine.ginEngine = gin.New()
+	return engine
+}
+
+// TestScopedMiddlewareRouting 测试路由分组与限定作用范围的中间件
+func TestScopedMiddlewareRouting(t *testing.T) {
+	engine := newRouteTestEngine(t)
+
+	_ = container.RegisterController[iTestAdminController](engine.Controller, &testGroupController{
+		name: "AdminController", group: "/api/admin/", route: "/users [GET]",
+	})
+	_ = container.RegisterController[iTestReportController](engine.Controller, &testGroupController{
+		name: "ReportController", group: "/api/report", route: "/daily [GET]",
+	})
+	_ = container.RegisterController[iTestPlainController](engine.Controller, &testPlainController{})
+
+	_ = container.RegisterMiddleware[iTestGlobalMiddleware](engine.Middleware, &testScopedMiddleware{
+		name: "global", order: 0,
+	})
+	_ = container.RegisterMiddleware[iTestAuthMiddleware](engine.Middleware, &testScopedMiddleware{
+		name: "auth", order: 300,
+		scope: common.MiddlewareScope{Groups: []string{"/api/admin"}},
+	})
+	_ = container.RegisterMiddleware[iTestAuditMiddleware](engine.Middleware, &testScopedMiddleware{
+		name: "audit", order: 400,
+		scope: common.MiddlewareScope{Paths: []string{"/api/admin/**", "/api/report/*"}},
+	})
+
+	if err := engine.registerMiddlewares(); err != nil {
+		t.Fatalf("注册中间件失败: %v", err)
+	}
+	if err := engine.registerControllers(); err != nil {
+		t.Fatalf("注册控制器失败: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "分组路由_命中分组和路径中间件", path: "/api/admin/users", expected: "global;auth;audit;"},
+		{name: "分组路由_仅命中路径中间件", path: "/api/report/daily", expected: "global;audit;"},
+		{name: "普通路由_仅全局中间件", path: "/api/public", expected: "global;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			engine.ginEngine.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("期望 200, 实际: %d", w.Code)
+			}
+			if w.Body.String() != tt.expected {
+				t.Errorf("期望中间件链 %q, 实际 %q", tt.expected, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestMatchPathPattern 测试路径模式匹配
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{pattern: "/api/admin/**", path: "/api/admin", expected: true},
+		{pattern: "/api/admin/**", path: "/api/admin/users/:id", expected: true},
+		{pattern: "/api/admin/**", path: "/api/administrator", expected: false},
+		{pattern: "/**", path: "/anything", expected: true},
+		{pattern: "/api/*/orders", path: "/api/v1/orders", expected: true},
+		{pattern: "/api/*/orders", path: "/api/v1/v2/orders", expected: false},
+		{pattern: "/api/users", path: "/api/users", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.path, func(t *testing.T) {
+			if got := matchPathPattern(tt.pattern, tt.path); got != tt.expected {
+				t.Errorf("matchPathPattern(%q, %q) = %v, 期望 %v", tt.pattern, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestJoinRoutePath 测试分组路径拼接
+func TestJoinRoutePath(t *testing.T) {
+	tests := []struct {
+		group    string
+		path     string
+		expected string
+	}{
+		{group: "", path: "/users", expected: "/users"},
+		{group: normalizeGroup("api/admin/"), path: "/users", expected: "/api/admin/users"},
+		{group: "/api/admin", path: "/users/", expected: "/api/admin/users/"},
+		{group: "/api/admin", path: "/files/*filepath", expected: "/api/admin/files/*filepath"},
+		{group: normalizeGroup("/"), path: "/users", expected: "/users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.group+tt.path, func(t *testing.T) {
+			if got := joinRoutePath(tt.group, tt.path); got != tt.expected {
+				t.Errorf("joinRoutePath(%q, %q) = %q, 期望 %q", tt.group, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
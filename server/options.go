@@ -0,0 +1,93 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/manager/configmgr"
+)
+
+// EngineOption Engine 选项，传给 NewEngine 或 ApplyOptions
+type EngineOption func(*Engine)
+
+// managerOverride 管理器替换项
+type managerOverride struct {
+	ifaceType reflect.Type
+	impl      common.IBaseManager
+}
+
+// WithConfigMap 使用内存中的配置数据替代配置文件（BuiltinConfig 可传 nil）
+// 数据结构与 YAML 配置文件一致，如 {"server": {"port": 8080}, "database": {...}}
+func WithConfigMap(cfg map[string]any) EngineOption {
+	return func(e *Engine) {
+		e.configManager = configmgr.NewMemoryConfigManager(cfg)
+	}
+}
+
+// WithConfigManager 使用已创建的配置管理器替代配置文件（BuiltinConfig 可传 nil）
+func WithConfigManager(mgr configmgr.IConfigManager) EngineOption {
+	return func(e *Engine) {
+		e.configManager = mgr
+	}
+}
+
+// WithManager 使用预先创建的 impl 替换接口 T 对应的内置管理器（未注册时新增）
+// 替换在内置管理器初始化之后、依赖注入之前生效；
+// 注意其他内置管理器在初始化时持有的仍是原实现（如锁管理器引用的缓存管理器）
+func WithManager[T common.IBaseManager](impl T) EngineOption {
+	return func(e *Engine) {
+		e.managerOverrides = append(e.managerOverrides, managerOverride{
+			ifaceType: reflect.TypeOf((*T)(nil)).Elem(),
+			impl:      impl,
+		})
+	}
+}
+
+// WithManagerFactory 注册自定义管理器工厂，Initialize 时与内置管理器一起按依赖顺序构建
+// 构建后的管理器可通过 inject:"" 注入，并参与健康检查和生命周期管理
+func WithManagerFactory(factories ...*ManagerFactory) EngineOption {
+	return func(e *Engine) {
+		e.managerFactories = append(e.managerFactories, factories...)
+	}
+}
+
+// WithGinOptions 创建 Gin 引擎时应用的选项（gin.New 的参数）
+func WithGinOptions(opts ...gin.OptionFunc) EngineOption {
+	return func(e *Engine) {
+		e.ginOptions = append(e.ginOptions, opts...)
+	}
+}
+
+// ApplyOptions 在 NewEngine 之后追加选项，需在 Initialize 之前调用
+// 适用于 Engine 由生成代码创建、测试中再替换配置或管理器的场景
+func (e *Engine) ApplyOptions(opts ...EngineOption) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.ginEngine != nil {
+		return fmt.Errorf("engine already initialized")
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return nil
+}
+
+// applyManagerOverrides 将 WithManager 设置的替换项应用到管理器容器
+func (e *Engine) applyManagerOverrides() error {
+	for _, override := range e.managerOverrides {
+		var err error
+		if e.Manager.GetByType(override.ifaceType) != nil {
+			err = e.Manager.ReplaceByType(override.ifaceType, override.impl)
+		} else {
+			err = e.Manager.RegisterByType(override.ifaceType, override.impl)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to override manager %v: %w", override.ifaceType, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/manager/cachemgr"
+	"github.com/lite-lake/litecore-go/manager/configmgr"
+)
+
+// testOptionCacheManager 用于替换内置缓存管理器的测试实现
+type testOptionCacheManager struct {
+	cachemgr.ICacheManager
+}
+
+func (m *testOptionCacheManager) ManagerName() string { return "TestOptionCacheManager" }
+func (m *testOptionCacheManager) Health() error       { return nil }
+func (m *testOptionCacheManager) OnStart() error      { return nil }
+func (m *testOptionCacheManager) OnStop() error       { return nil }
+
+// newOptionTestEngine 创建带选项的空容器测试引擎
+func newOptionTestEngine(opts ...EngineOption) *Engine {
+	entityContainer := container.NewEntityContainer()
+	repositoryContainer := container.NewRepositoryContainer(entityContainer)
+	serviceContainer := container.NewServiceContainer(repositoryContainer)
+	controllerContainer := container.NewControllerContainer(serviceContainer)
+	middlewareContainer := container.NewMiddlewareContainer(serviceContainer)
+
+	return NewEngine(nil, entityContainer, repositoryContainer, serviceContainer,
+		controllerContainer, middlewareContainer, nil, nil, opts...)
+}
+
+// TestEngineOptions 测试 NewEngine 选项
+func TestEngineOptions(t *testing.T) {
+	t.Run("WithConfigMap_创建内存配置管理器", func(t *testing.T) {
+		engine := newOptionTestEngine(WithConfigMap(map[string]any{
+			"server": map[string]any{"port": 9090},
+		}))
+		if engine.configManager == nil {
+			t.Fatal("期望设置配置管理器")
+		}
+		port, err := engine.configManager.Get("server.port")
+		if err != nil || port != 9090 {
+			t.Errorf("期望 server.port = 9090, 实际 = %v, err = %v", port, err)
+		}
+	})
+
+	t.Run("WithConfigManager_使用传入的管理器", func(t *testing.T) {
+		mgr := configmgr.NewMemoryConfigManager(map[string]any{})
+		engine := newOptionTestEngine(WithConfigManager(mgr))
+		if engine.configManager != mgr {
+			t.Error("期望使用传入的配置管理器")
+		}
+	})
+
+	t.Run("WithManager_登记替换项", func(t *testing.T) {
+		impl := &testOptionCacheManager{}
+		engine := newOptionTestEngine(WithManager[cachemgr.ICacheManager](impl))
+		if len(engine.managerOverrides) != 1 {
+			t.Fatalf("期望 1 个替换项, 实际 %d", len(engine.managerOverrides))
+		}
+		override := engine.managerOverrides[0]
+		if override.ifaceType != reflect.TypeOf((*cachemgr.ICacheManager)(nil)).Elem() || override.impl != impl {
+			t.Errorf("替换项不正确: %+v", override)
+		}
+	})
+
+	t.Run("WithGinOptions_累加选项", func(t *testing.T) {
+		noop := func(*gin.Engine) {}
+		engine := newOptionTestEngine(WithGinOptions(noop), WithGinOptions(noop, noop))
+		if len(engine.ginOptions) != 3 {
+			t.Errorf("期望 3 个 Gin 选项, 实际 %d", len(engine.ginOptions))
+		}
+	})
+
+	t.Run("未设置配置_Initialize返回错误", func(t *testing.T) {
+		engine := newOptionTestEngine()
+		if err := engine.Initialize(); err == nil {
+			t.Error("未设置 BuiltinConfig 和配置管理器时期望返回错误")
+		}
+	})
+}
+
+// TestEngineApplyOptions 测试 NewEngine 之后追加选项
+func TestEngineApplyOptions(t *testing.T) {
+	t.Run("Initialize之前_选项生效", func(t *testing.T) {
+		engine := newOptionTestEngine()
+		if err := engine.ApplyOptions(WithConfigMap(map[string]any{})); err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if engine.configManager == nil {
+			t.Error("期望设置配置管理器")
+		}
+	})
+
+	t.Run("Initialize之后_返回错误", func(t *testing.T) {
+		engine := newOptionTestEngine()
+		engine.ginEngine = gin.New()
+		if err := engine.ApplyOptions(WithConfigMap(map[string]any{})); err == nil {
+			t.Error("初始化后追加选项期望返回错误")
+		}
+		if engine.configManager != nil {
+			t.Error("初始化后追加的选项不应生效")
+		}
+	})
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/manager/cachemgr"
+	"github.com/lite-lake/litecore-go/manager/databasemgr"
+	"github.com/lite-lake/litecore-go/manager/limitermgr"
+	"github.com/lite-lake/litecore-go/manager/lockmgr"
+	"github.com/lite-lake/litecore-go/manager/mqmgr"
+)
+
+// 就绪状态
+const (
+	ReadinessOK       = "ok"        // 所有检查通过
+	ReadinessDegraded = "degraded"  // 仅非关键检查失败，仍可接收流量
+	ReadinessNotReady = "not_ready" // 关键检查失败或尚未完成启动
+
+	ReadinessMaintenance = "maintenance" // 处于维护模式，/api/ready 返回 503
+)
+
+// 检查项状态
+const (
+	HealthCheckOK        = "ok"
+	HealthCheckUnhealthy = "unhealthy"
+	HealthCheckPending   = "pending" // 尚未执行
+)
+
+// startupGatePollInterval 启动门控轮询关键检查的间隔
+const startupGatePollInterval = time.Second
+
+// ReadinessConfig 就绪检查配置
+type ReadinessConfig struct {
+	CheckTimeout    time.Duration `yaml:"check_timeout"`    // 单项检查超时，默认 3s
+	RefreshInterval time.Duration `yaml:"refresh_interval"` // 检查结果刷新间隔，默认 10s
+	StartupTimeout  time.Duration `yaml:"startup_timeout"`  // 启动时等待关键检查通过的最长时间，默认 30s，0 表示不等待
+	Critical        []string      `yaml:"critical"`         // 标记为关键的检查项名称（覆盖默认值）
+	NonCritical     []string      `yaml:"non_critical"`     // 标记为非关键的检查项名称（覆盖默认值）
+}
+
+// DefaultReadinessConfig 返回默认的就绪检查配置
+func DefaultReadinessConfig() *ReadinessConfig {
+	return &ReadinessConfig{
+		CheckTimeout:    3 * time.Second,
+		RefreshInterval: 10 * time.Second,
+		StartupTimeout:  30 * time.Second,
+	}
+}
+
+// Validate 验证就绪检查配置
+func (c *ReadinessConfig) Validate() error {
+	var errs []error
+	if c.CheckTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.readiness.check_timeout: must be greater than 0"))
+	}
+	if c.RefreshInterval <= 0 {
+		errs = append(errs, fmt.Errorf("server.readiness.refresh_interval: must be greater than 0"))
+	}
+	if c.StartupTimeout < 0 {
+		errs = append(errs, fmt.Errorf("server.readiness.startup_timeout: cannot be negative"))
+	}
+	for _, name := range c.Critical {
+		for _, other := range c.NonCritical {
+			if name == other {
+				errs = append(errs, fmt.Errorf("server.readiness: %q listed as both critical and non_critical", name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HealthCheck 健康检查项
+type HealthCheck struct {
+	Name     string                          // 检查项名称，唯一
+	Critical bool                            // 关键检查失败时 /api/ready 返回 not_ready(503)，非关键检查失败时返回 degraded(200)
+	Timeout  time.Duration                   // 检查超时，0 表示使用 readiness.check_timeout
+	Check    func(ctx context.Context) error // 检查函数，返回 nil 表示健康
+}
+
+// HealthCheckResult 健康检查结果
+type HealthCheckResult struct {
+	Status    string    `json:"status"`          // ok/unhealthy/pending
+	Critical  bool      `json:"critical"`        // 是否关键检查
+	Error     string    `json:"error,omitempty"` // 失败原因
+	Duration  string    `json:"duration"`        // 检查耗时
+	CheckedAt time.Time `json:"checked_at"`      // 检查时间
+}
+
+// ReadinessReport 就绪报告
+type ReadinessReport struct {
+	Status string                       `json:"status"` // ok/degraded/not_ready
+	Checks map[string]HealthCheckResult `json:"checks"` // 各检查项结果
+}
+
+// readinessChecker 就绪检查器
+// 并发执行所有检查并缓存结果，/api/ready 直接读取缓存，后台按 refresh_interval 刷新
+type readinessChecker struct {
+	cfg *ReadinessConfig
+
+	mu      sync.RWMutex
+	checks  []HealthCheck
+	results map[string]HealthCheckResult
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newReadinessChecker 创建就绪检查器
+func newReadinessChecker(cfg *ReadinessConfig) *readinessChecker {
+	if cfg == nil {
+		cfg = DefaultReadinessConfig()
+	}
+	return &readinessChecker{
+		cfg:     cfg,
+		results: make(map[string]HealthCheckResult),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// add 添加检查项，配置中的 critical/non_critical 覆盖检查项自身的关键性；同名检查项后者覆盖前者
+func (r *readinessChecker) add(check HealthCheck) {
+	for _, name := range r.cfg.Critical {
+		if name == check.Name {
+			check.Critical = true
+		}
+	}
+	for _, name := range r.cfg.NonCritical {
+		if name == check.Name {
+			check.Critical = false
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.checks {
+		if existing.Name == check.Name {
+			r.checks[i] = check
+			r.results[check.Name] = HealthCheckResult{Status: HealthCheckPending, Critical: check.Critical}
+			return
+		}
+	}
+	r.checks = append(r.checks, check)
+	r.results[check.Name] = HealthCheckResult{Status: HealthCheckPending, Critical: check.Critical}
+}
+
+// refresh 并发执行所有检查并更新缓存
+func (r *readinessChecker) refresh(ctx context.Context) ReadinessReport {
+	r.mu.RLock()
+	checks := make([]HealthCheck, len(r.checks))
+	copy(checks, r.checks)
+	r.mu.RUnlock()
+
+	results := make([]HealthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	for i, check := range checks {
+		r.results[check.Name] = results[i]
+	}
+	r.mu.Unlock()
+
+	return r.report()
+}
+
+// run 执行单个检查，超时或 panic 视为失败
+func (r *readinessChecker) run(ctx context.Context, check HealthCheck) HealthCheckResult {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = r.cfg.CheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- fmt.Errorf("panic: %v", rec)
+			}
+		}()
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	result := HealthCheckResult{
+		Status:    HealthCheckOK,
+		Critical:  check.Critical,
+		Duration:  time.Since(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = HealthCheckUnhealthy
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// report 返回缓存的就绪报告
+// 关键检查失败或尚未执行时为 not_ready，仅非关键检查失败时为 degraded
+func (r *readinessChecker) report() ReadinessReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := ReadinessReport{
+		Status: ReadinessOK,
+		Checks: make(map[string]HealthCheckResult, len(r.results)),
+	}
+	for name, result := range r.results {
+		report.Checks[name] = result
+		if result.Status == HealthCheckOK {
+			continue
+		}
+		if result.Critical {
+			report.Status = ReadinessNotReady
+		} else if report.Status == ReadinessOK {
+			report.Status = ReadinessDegraded
+		}
+	}
+	return report
+}
+
+// waitCritical 启动门控：轮询直到所有关键检查通过
+// 超过 readiness.startup_timeout 仍未通过时返回失败的关键检查项
+func (r *readinessChecker) waitCritical(ctx context.Context) error {
+	var deadline <-chan time.Time
+	if r.cfg.StartupTimeout > 0 {
+		timer := time.NewTimer(r.cfg.StartupTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		report := r.refresh(ctx)
+		if report.Status != ReadinessNotReady {
+			return nil
+		}
+		if deadline == nil {
+			// 不等待：记录当前结果后直接放行
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("critical checks not healthy within %s: %s", r.cfg.StartupTimeout, failingCritical(report))
+		case <-time.After(startupGatePollInterval):
+		}
+	}
+}
+
+// failingCritical 返回失败的关键检查项描述
+func failingCritical(report ReadinessReport) string {
+	return describeFailing(report, true)
+}
+
+// failingChecks 返回所有失败的检查项描述
+func failingChecks(report ReadinessReport) string {
+	return describeFailing(report, false)
+}
+
+// describeFailing 返回失败检查项描述，criticalOnly 为 true 时仅包含关键检查
+func describeFailing(report ReadinessReport, criticalOnly bool) string {
+	failing := make([]string, 0)
+	for name, result := range report.Checks {
+		if (result.Critical || !criticalOnly) && result.Status != HealthCheckOK {
+			failing = append(failing, fmt.Sprintf("%s (%s)", name, result.Error))
+		}
+	}
+	sort.Strings(failing)
+	return strings.Join(failing, ", ")
+}
+
+// start 后台按 refresh_interval 刷新检查结果，直到 stop 被调用
+func (r *readinessChecker) start(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// stop 停止后台刷新
+func (r *readinessChecker) stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// AddHealthCheck 注册自定义健康检查项（如下游服务、第三方 API）
+// 需在 Start 之前调用；同名检查项后者覆盖前者
+func (e *Engine) AddHealthCheck(check HealthCheck) error {
+	if check.Name == "" {
+		return fmt.Errorf("health check name is required")
+	}
+	if check.Check == nil {
+		return fmt.Errorf("health check %s has no check function", check.Name)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.started {
+		return fmt.Errorf("cannot add health check %s after engine started", check.Name)
+	}
+	e.healthChecks = append(e.healthChecks, check)
+	if e.readiness != nil {
+		e.readiness.add(check)
+	}
+	return nil
+}
+
+// Readiness 返回当前缓存的就绪报告，处于维护模式时状态为 maintenance
+func (e *Engine) Readiness() ReadinessReport {
+	if e.readiness == nil || !e.ready.Load() {
+		report := ReadinessReport{Status: ReadinessNotReady}
+		if e.readiness != nil {
+			report.Checks = e.readiness.report().Checks
+		}
+		return report
+	}
+	report := e.readiness.report()
+	if e.maintenance != nil && e.maintenance.active() {
+		report.Status = ReadinessMaintenance
+	}
+	return report
+}
+
+// discoverHealthCheckers 自动发现实现 common.IHealthChecker 的 Repository 和 Service，按名称返回
+func (e *Engine) discoverHealthCheckers() map[string]common.IHealthChecker {
+	checkers := make(map[string]common.IHealthChecker)
+	if e.Repository != nil {
+		for _, repo := range e.Repository.GetAll() {
+			if checker, ok := repo.(common.IHealthChecker); ok {
+				checkers[repo.RepositoryName()] = checker
+			}
+		}
+	}
+	if e.Service != nil {
+		for _, svc := range e.Service.GetAll() {
+			if checker, ok := svc.(common.IHealthChecker); ok {
+				checkers[svc.ServiceName()] = checker
+			}
+		}
+	}
+	return checkers
+}
+
+// managerHealthCheck 将 Manager 的 Health 包装为检查项
+// 数据库、缓存、锁、限流、消息队列等外部依赖默认为关键检查；
+// 未启用的依赖（none 驱动）不参与检查，返回 false
+func managerHealthCheck(mgr common.IBaseManager) (HealthCheck, bool) {
+	if driver, ok := mgr.(interface{ Driver() string }); ok && driver.Driver() == "none" {
+		return HealthCheck{}, false
+	}
+
+	critical := false
+	switch mgr.(type) {
+	case databasemgr.IDatabaseManager, cachemgr.ICacheManager, lockmgr.ILockManager,
+		limitermgr.ILimiterManager, mqmgr.IMQManager:
+		critical = true
+	}
+
+	return HealthCheck{
+		Name:     mgr.ManagerName(),
+		Critical: critical,
+		Check: func(context.Context) error {
+			return mgr.Health()
+		},
+	}, true
+}
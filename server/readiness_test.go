@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+// newTestReadinessChecker 创建使用较短超时的就绪检查器
+func newTestReadinessChecker(cfg *ReadinessConfig) *readinessChecker {
+	if cfg == nil {
+		cfg = &ReadinessConfig{
+			CheckTimeout:    50 * time.Millisecond,
+			RefreshInterval: time.Hour,
+			StartupTimeout:  0,
+		}
+	}
+	return newReadinessChecker(cfg)
+}
+
+func healthyCheck(context.Context) error { return nil }
+
+func failingCheck(context.Context) error { return errors.New("connection refused") }
+
+// TestReadinessChecker_Report 测试就绪状态汇总
+func TestReadinessChecker_Report(t *testing.T) {
+	tests := []struct {
+		name     string
+		checks   []HealthCheck
+		expected string
+	}{
+		{
+			name: "全部通过_ok",
+			checks: []HealthCheck{
+				{Name: "db", Critical: true, Check: healthyCheck},
+				{Name: "search", Check: healthyCheck},
+			},
+			expected: ReadinessOK,
+		},
+		{
+			name: "非关键检查失败_degraded",
+			checks: []HealthCheck{
+				{Name: "db", Critical: true, Check: healthyCheck},
+				{Name: "search", Check: failingCheck},
+			},
+			expected: ReadinessDegraded,
+		},
+		{
+			name: "关键检查失败_not_ready",
+			checks: []HealthCheck{
+				{Name: "db", Critical: true, Check: failingCheck},
+				{Name: "search", Check: failingCheck},
+			},
+			expected: ReadinessNotReady,
+		},
+		{
+			name: "检查超时_视为失败",
+			checks: []HealthCheck{
+				{Name: "db", Critical: true, Check: func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				}},
+			},
+			expected: ReadinessNotReady,
+		},
+		{
+			name: "检查panic_视为失败",
+			checks: []HealthCheck{
+				{Name: "db", Critical: true, Check: func(context.Context) error { panic("boom") }},
+			},
+			expected: ReadinessNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newTestReadinessChecker(nil)
+			for _, check := range tt.checks {
+				checker.add(check)
+			}
+
+			report := checker.refresh(context.Background())
+			if report.Status != tt.expected {
+				t.Errorf("期望状态 %s, 实际 %s (%+v)", tt.expected, report.Status, report.Checks)
+			}
+		})
+	}
+}
+
+// TestReadinessChecker_Cached 测试检查结果缓存
+func TestReadinessChecker_Cached(t *testing.T) {
+	var calls atomic.Int32
+	checker := newTestReadinessChecker(nil)
+	checker.add(HealthCheck{Name: "db", Critical: true, Check: func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}})
+
+	if report := checker.report(); report.Status != ReadinessNotReady || report.Checks["db"].Status != HealthCheckPending {
+		t.Errorf("首次检查前期望 pending/not_ready, 实际 %+v", report)
+	}
+
+	checker.refresh(context.Background())
+	for i := 0; i < 5; i++ {
+		checker.report()
+	}
+	if calls.Load() != 1 {
+		t.Errorf("期望读取缓存不触发检查, 实际调用 %d 次", calls.Load())
+	}
+}
+
+// TestReadinessChecker_CriticalOverride 测试通过配置覆盖检查项关键性
+func TestReadinessChecker_CriticalOverride(t *testing.T) {
+	checker := newTestReadinessChecker(&ReadinessConfig{
+		CheckTimeout:    50 * time.Millisecond,
+		RefreshInterval: time.Hour,
+		Critical:        []string{"search"},
+		NonCritical:     []string{"db"},
+	})
+	checker.add(HealthCheck{Name: "db", Critical: true, Check: failingCheck})
+	checker.add(HealthCheck{Name: "search", Check: healthyCheck})
+
+	report := checker.refresh(context.Background())
+	if report.Status != ReadinessDegraded {
+		t.Errorf("期望 degraded, 实际 %s", report.Status)
+	}
+	if !report.Checks["search"].Critical || report.Checks["db"].Critical {
+		t.Errorf("关键性覆盖不正确: %+v", report.Checks)
+	}
+}
+
+// TestReadinessChecker_WaitCritical 测试启动门控
+func TestReadinessChecker_WaitCritical(t *testing.T) {
+	t.Run("关键依赖恢复_放行", func(t *testing.T) {
+		var healthy atomic.Bool
+		checker := newTestReadinessChecker(&ReadinessConfig{
+			CheckTimeout:    50 * time.Millisecond,
+			RefreshInterval: time.Hour,
+			StartupTimeout:  5 * time.Second,
+		})
+		checker.add(HealthCheck{Name: "db", Critical: true, Check: func(context.Context) error {
+			if !healthy.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		}})
+		checker.add(HealthCheck{Name: "search", Check: failingCheck})
+
+		time.AfterFunc(100*time.Millisecond, func() { healthy.Store(true) })
+		if err := checker.waitCritical(context.Background()); err != nil {
+			t.Fatalf("期望门控通过, 实际 %v", err)
+		}
+		if status := checker.report().Status; status != ReadinessDegraded {
+			t.Errorf("期望 degraded, 实际 %s", status)
+		}
+	})
+
+	t.Run("关键依赖超时_返回失败项", func(t *testing.T) {
+		checker := newTestReadinessChecker(&ReadinessConfig{
+			CheckTimeout:    50 * time.Millisecond,
+			RefreshInterval: time.Hour,
+			StartupTimeout:  100 * time.Millisecond,
+		})
+		checker.add(HealthCheck{Name: "db", Critical: true, Check: failingCheck})
+
+		err := checker.waitCritical(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "db (connection refused)") {
+			t.Errorf("期望返回失败的关键检查, 实际 %v", err)
+		}
+	})
+
+	t.Run("startup_timeout为0_不等待", func(t *testing.T) {
+		checker := newTestReadinessChecker(nil)
+		checker.add(HealthCheck{Name: "db", Critical: true, Check: failingCheck})
+
+		if err := checker.waitCritical(context.Background()); err != nil {
+			t.Errorf("期望直接放行, 实际 %v", err)
+		}
+	})
+}
+
+// TestHandleReadiness 测试就绪探针响应
+func TestHandleReadiness(t *testing.T) {
+	engine := newRouteTestEngine(t)
+	engine.Manager = container.NewManagerContainer()
+	_ = container.RegisterManager[iTestSearchManager](engine.Manager, &testSearchManager{})
+	engine.readiness = newTestReadinessChecker(nil)
+	engine.readiness.add(HealthCheck{Name: "db", Critical: true, Check: healthyCheck})
+	engine.readiness.add(HealthCheck{Name: "TestSearchManager", Check: failingCheck})
+	engine.registerSystemRoutes()
+
+	serve := func() (int, map[string]any) {
+		req, _ := http.NewRequest("GET", "/api/ready", nil)
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, req)
+		var body map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+
+	t.Run("启动门控通过前_not_ready", func(t *testing.T) {
+		code, body := serve()
+		if code != http.StatusServiceUnavailable || body["status"] != ReadinessNotReady {
+			t.Errorf("期望 503 not_ready, 实际 %d %v", code, body["status"])
+		}
+	})
+
+	t.Run("非关键检查失败_degraded", func(t *testing.T) {
+		engine.readiness.refresh(context.Background())
+		engine.ready.Store(true)
+
+		code, body := serve()
+		if code != http.StatusOK || body["status"] != ReadinessDegraded {
+			t.Errorf("期望 200 degraded, 实际 %d %v", code, body["status"])
+		}
+		checks, _ := body["checks"].(map[string]any)
+		if _, ok := checks["TestSearchManager"]; !ok {
+			t.Errorf("期望响应包含检查项详情, 实际 %v", body)
+		}
+		managers, _ := body["managers"].(map[string]any)
+		if status, _ := managers["TestSearchManager"].(string); !strings.HasPrefix(status, "unhealthy: ") || len(managers) != 1 {
+			t.Errorf("期望兼容的 managers 字段, 实际 %v", body["managers"])
+		}
+	})
+}
+
+type iTestPaymentService interface {
+	common.IBaseService
+}
+
+type iTestPlainService interface {
+	common.IBaseService
+}
+
+// testPaymentService 实现 common.IHealthChecker 的测试服务
+type testPaymentService struct {
+	name string
+	err  error
+}
+
+func (s *testPaymentService) ServiceName() string              { return s.name }
+func (s *testPaymentService) OnStart() error                   { return nil }
+func (s *testPaymentService) OnStop() error                    { return nil }
+func (s *testPaymentService) Health(ctx context.Context) error { return s.err }
+
+// testPlainService 未实现 common.IHealthChecker 的测试服务
+type testPlainService struct{}
+
+func (s *testPlainService) ServiceName() string { return "PlainService" }
+func (s *testPlainService) OnStart() error      { return nil }
+func (s *testPlainService) OnStop() error       { return nil }
+
+// TestDiscoverHealthCheckers 测试自动发现 Service 健康检查并纳入就绪检查
+func TestDiscoverHealthCheckers(t *testing.T) {
+	engine := newRouteTestEngine(t)
+	_ = container.RegisterService[iTestPaymentService](engine.Service, &testPaymentService{
+		name: "PaymentService", err: errors.New("gateway timeout"),
+	})
+	_ = container.RegisterService[iTestPlainService](engine.Service, &testPlainService{})
+
+	checkers := engine.discoverHealthCheckers()
+	if len(checkers) != 1 {
+		t.Fatalf("期望发现 1 个健康检查者, 实际 %d", len(checkers))
+	}
+	if _, ok := checkers["PaymentService"]; !ok {
+		t.Fatalf("期望按 ServiceName 命名, 实际 %v", checkers)
+	}
+
+	readiness := newTestReadinessChecker(nil)
+	for name, checker := range checkers {
+		readiness.add(HealthCheck{Name: name, Check: checker.Health})
+	}
+	report := readiness.refresh(context.Background())
+	if report.Status != ReadinessDegraded {
+		t.Errorf("期望非关键组件失败时 degraded, 实际 %s", report.Status)
+	}
+	result := report.Checks["PaymentService"]
+	if result.Critical || !strings.Contains(result.Error, "gateway timeout") {
+		t.Errorf("期望非关键检查并包含错误信息, 实际 %+v", result)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+)
+
+// routeLimits 控制器路由的请求超时和请求体大小限制（0 表示不限制）
+type routeLimits struct {
+	timeout      time.Duration
+	maxBodyBytes int64
+}
+
+// routeLimitsFor 返回控制器路由的限制：默认取 server 配置，控制器实现对应接口时覆盖
+func (e *Engine) routeLimitsFor(ctrl common.IBaseController) routeLimits {
+	limits := routeLimits{
+		timeout:      e.serverConfig.RequestTimeout,
+		maxBodyBytes: e.serverConfig.MaxBodyBytes,
+	}
+	if c, ok := ctrl.(common.IRequestTimeoutController); ok {
+		limits.timeout = c.RequestTimeout()
+	}
+	if c, ok := ctrl.(common.IBodyLimitController); ok {
+		limits.maxBodyBytes = c.MaxBodyBytes()
+	}
+	return limits
+}
+
+// handlers 返回实现限制的处理函数，置于路由处理链最前
+func (l routeLimits) handlers() []gin.HandlerFunc {
+	var handlers []gin.HandlerFunc
+	if l.maxBodyBytes > 0 {
+		handlers = append(handlers, bodyLimitHandler(l.maxBodyBytes))
+	}
+	if l.timeout > 0 {
+		handlers = append(handlers, requestTimeoutHandler(l.timeout))
+	}
+	return handlers
+}
+
+// requestTimeoutHandler 为请求 context 设置 deadline，超时且处理链未写出响应时提交 408 错误，由错误处理中间件渲染
+// 处理链同步执行，超时不会中断处理函数，依赖下游通过 context 感知 deadline 并尽快返回
+func requestTimeoutHandler(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			_ = c.Error(errRequestTimeout())
+			c.Abort()
+		}
+	}
+}
+
+// bodyLimitHandler 限制请求体大小
+// Content-Length 超限时直接中止并提交 413 错误；未声明长度时读取超限返回错误，处理链未写出响应时提交 413 错误；
+// 错误由错误处理中间件统一渲染
+func bodyLimitHandler(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			_ = c.Error(errPayloadTooLarge())
+			c.Abort()
+			return
+		}
+		if c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, limit)}
+		c.Request.Body = body
+
+		c.Next()
+
+		if body.exceeded && !c.Writer.Written() {
+			_ = c.Error(errPayloadTooLarge())
+			c.Abort()
+		}
+	}
+}
+
+// errRequestTimeout 请求处理超时错误（408）
+func errRequestTimeout() *common.BusinessError {
+	return common.NewBusinessError(common.CodeRequestTimeout, "request timeout")
+}
+
+// errPayloadTooLarge 请求体过大错误（413）
+func errPayloadTooLarge() *common.BusinessError {
+	return common.NewBusinessError(common.CodePayloadTooLarge, "request body too large")
+}
+
+// limitedBody 记录读取请求体时是否超过大小限制
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+// Read 读取请求体
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+// testLimitedController 声明了超时和请求体限制的控制器
+// 读取请求体后等待 wait 再返回读取的字节数，请求 context 提前结束时不写出响应
+type testLimitedController struct {
+	route        string
+	timeout      time.Duration
+	maxBodyBytes int64
+	wait         time.Duration
+}
+
+func (c *testLimitedController) ControllerName() string        { return "LimitedController" }
+func (c *testLimitedController) GetRouter() string             { return c.route }
+func (c *testLimitedController) RequestTimeout() time.Duration { return c.timeout }
+func (c *testLimitedController) MaxBodyBytes() int64           { return c.maxBodyBytes }
+func (c *testLimitedController) Handle(ctx *gin.Context) {
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return
+	}
+	select {
+	case <-ctx.Request.Context().Done():
+		return
+	case <-time.After(c.wait):
+	}
+	ctx.String(http.StatusOK, "%d", len(body))
+}
+
+type iTestLimitedController interface{ common.IBaseController }
+
+// newLimitTestEngine 创建注册了限制控制器的测试引擎
+func newLimitTestEngine(t *testing.T, ctrl common.IBaseController) *Engine {
+	t.Helper()
+	engine := newRouteTestEngine(t)
+	engine.installErrorHandling(engine.ginEngine)
+	_ = container.RegisterController[iTestLimitedController](engine.Controller, ctrl)
+	if err := engine.registerControllers(); err != nil {
+		t.Fatalf("注册控制器失败: %v", err)
+	}
+	return engine
+}
+
+// assertAPIError 断言响应为指定状态码和业务码的 APIErrorResponse
+func assertAPIError(t *testing.T, w *httptest.ResponseRecorder, status int, code common.BusinessErrorCode) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("期望状态码 %d, 实际 %d, body=%s", status, w.Code, w.Body.String())
+	}
+	var resp common.APIErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v, body=%s", err, w.Body.String())
+	}
+	if resp.Code != int(code) || resp.Message == "" {
+		t.Errorf("响应体不正确: %+v", resp)
+	}
+}
+
+// TestRequestTimeout 测试控制器请求超时
+func TestRequestTimeout(t *testing.T) {
+	t.Run("超时_返回408", func(t *testing.T) {
+		engine := newLimitTestEngine(t, &testLimitedController{
+			route: "/api/slow [GET]", timeout: 20 * time.Millisecond, wait: time.Second,
+		})
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/slow", nil))
+		assertAPIError(t, w, http.StatusRequestTimeout, common.CodeRequestTimeout)
+	})
+
+	t.Run("未超时_正常响应", func(t *testing.T) {
+		engine := newLimitTestEngine(t, &testLimitedController{
+			route: "/api/fast [GET]", timeout: time.Second,
+		})
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/fast", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("期望 200, 实际 %d", w.Code)
+		}
+	})
+
+	t.Run("使用server配置的默认超时", func(t *testing.T) {
+		engine := newRouteTestEngine(t)
+		engine.serverConfig.RequestTimeout = 20 * time.Millisecond
+		_ = container.RegisterController[iTestLimitedController](engine.Controller, &testGroupController{
+			name: "BlockingController", route: "/api/blocking [GET]",
+		})
+		if err := engine.registerControllers(); err != nil {
+			t.Fatalf("注册控制器失败: %v", err)
+		}
+		routes := engine.Routes()
+		if len(routes) != 1 || routes[0].Timeout != 20*time.Millisecond {
+			t.Errorf("路由表未记录默认超时: %+v", routes)
+		}
+	})
+}
+
+// TestBodyLimit 测试控制器请求体大小限制
+func TestBodyLimit(t *testing.T) {
+	newEngine := func(t *testing.T) *Engine {
+		return newLimitTestEngine(t, &testLimitedController{
+			route: "/api/upload [POST]", maxBodyBytes: 8,
+		})
+	}
+
+	t.Run("Content-Length超限_返回413", func(t *testing.T) {
+		engine := newEngine(t)
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/upload", strings.NewReader("0123456789")))
+		assertAPIError(t, w, http.StatusRequestEntityTooLarge, common.CodePayloadTooLarge)
+	})
+
+	t.Run("未声明长度_读取超限返回413", func(t *testing.T) {
+		engine := newEngine(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/upload", io.NopCloser(strings.NewReader("0123456789")))
+		req.ContentLength = -1
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, req)
+		assertAPIError(t, w, http.StatusRequestEntityTooLarge, common.CodePayloadTooLarge)
+	})
+
+	t.Run("未超限_正常读取", func(t *testing.T) {
+		engine := newEngine(t)
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/upload", strings.NewReader("01234567")))
+		if w.Code != http.StatusOK || w.Body.String() != "8" {
+			t.Errorf("期望 200 且读取 8 字节, 实际 %d %s", w.Code, w.Body.String())
+		}
+	})
+}
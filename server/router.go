@@ -10,35 +10,86 @@ import (
 	"github.com/lite-lake/litecore-go/common/deployinfo"
 )
 
-// registerRoute 注册自定义路由
-func (e *Engine) registerRoute(method, path string, handler gin.HandlerFunc) {
+// registerRoute 在公共端口注册自定义路由
+// handlers 依次执行，最后一个为控制器处理函数，其余为作用于该路由的中间件
+func (e *Engine) registerRoute(method, path string, handlers ...gin.HandlerFunc) {
+	registerRouteOn(e.ginEngine, method, path, handlers...)
+}
+
+// registerRouteOn 在指定 Gin 引擎上注册路由
+func registerRouteOn(router gin.IRoutes, method, path string, handlers ...gin.HandlerFunc) {
 	switch strings.ToUpper(method) {
 	case "GET":
-		e.ginEngine.GET(path, handler)
+		router.GET(path, handlers...)
 	case "POST":
-		e.ginEngine.POST(path, handler)
+		router.POST(path, handlers...)
 	case "PUT":
-		e.ginEngine.PUT(path, handler)
+		router.PUT(path, handlers...)
 	case "DELETE":
-		e.ginEngine.DELETE(path, handler)
+		router.DELETE(path, handlers...)
 	case "PATCH":
-		e.ginEngine.PATCH(path, handler)
+		router.PATCH(path, handlers...)
 	case "HEAD":
-		e.ginEngine.HEAD(path, handler)
+		router.HEAD(path, handlers...)
 	case "OPTIONS":
-		e.ginEngine.OPTIONS(path, handler)
+		router.OPTIONS(path, handlers...)
 	case "ANY":
-		e.ginEngine.Any(path, handler)
+		router.Any(path, handlers...)
 	default:
-		e.ginEngine.GET(path, handler)
+		router.GET(path, handlers...)
 	}
 }
 
-// registerSystemRoutes 注册系统路由（/api/health、/api/ready）
-// 系统路由由 Engine 直接注册，不经过 Controller Container，所有 app 自动获得
+// systemRoute 系统路由
+type systemRoute struct {
+	method  string
+	path    string
+	handler gin.HandlerFunc
+}
+
+// registerSystemRoutes 注册系统路由（/api/health、/api/ready、/api/routes、/api/maintenance、/api/startup、/api/dependencies）
+// 系统路由由 Engine 直接注册，不经过 Controller Container，所有 app 自动获得，维护模式下不拦截；
+// 启用管理端监听器时挂载到管理端，不再暴露在公共端口；
+// 路由表 /api/routes、维护模式状态 GET /api/maintenance、启动报告 /api/startup 和依赖图 /api/dependencies
+// 仅在管理端或 debug 模式下注册，防止生产环境泄露路由信息；切换维护模式的 POST /api/maintenance 仅在管理端注册
 func (e *Engine) registerSystemRoutes() {
-	e.ginEngine.GET("/api/health", e.handleLiveness)
-	e.ginEngine.GET("/api/ready", e.handleReadiness)
+	e.routeRegistry = newRouteRegistry()
+	e.systemPaths = make(map[string]bool)
+
+	router, onAdmin := e.ginEngine, false
+	if e.adminEnabled() {
+		router, onAdmin = e.adminEngine, true
+	}
+
+	systemRoutes := []systemRoute{
+		{http.MethodGet, "/api/health", e.handleLiveness},
+		{http.MethodGet, "/api/ready", e.handleReadiness},
+	}
+	if onAdmin || e.serverConfig.Mode == "debug" {
+		systemRoutes = append(systemRoutes,
+			systemRoute{http.MethodGet, "/api/routes", e.handleRoutes},
+			systemRoute{http.MethodGet, "/api/maintenance", e.handleMaintenance},
+			systemRoute{http.MethodGet, "/api/startup", e.handleStartupReport},
+			systemRoute{http.MethodGet, "/api/dependencies", e.handleDependencyGraph},
+		)
+	}
+	// 切换维护模式会改变服务状态，仅挂载到管理端（debug 模式下也不在公共端口暴露）
+	if onAdmin {
+		systemRoutes = append(systemRoutes, systemRoute{http.MethodPost, "/api/maintenance", e.handleSetMaintenance})
+	}
+	for _, route := range systemRoutes {
+		router.Handle(route.method, route.path, route.handler)
+		_ = e.routeRegistry.add(RouteInfo{
+			Method:      route.method,
+			Path:        route.path,
+			Controller:  systemRouteController,
+			Middlewares: e.routeMiddlewareNames(onAdmin, nil),
+			Admin:       onAdmin,
+		})
+		if !onAdmin {
+			e.systemPaths[route.path] = true
+		}
+	}
 }
 
 // handleLiveness 存活探针：进程存活即返回 ok
@@ -51,51 +102,45 @@ func (e *Engine) handleLiveness(c *gin.Context) {
 	})
 }
 
-// handleReadiness 就绪探针：所有 Manager 健康且引擎启动完成后才返回 ok
+// handleReadiness 就绪探针：返回缓存的就绪检查结果
+// 启动门控通过前或关键检查失败时返回 503 not_ready；维护模式下返回 503 maintenance；
+// 仅非关键检查失败时返回 200 degraded
 func (e *Engine) handleReadiness(c *gin.Context) {
-	e.mu.RLock()
-	isReady := e.ready
-	e.mu.RUnlock()
-
 	info := deployinfo.Get()
 
-	if !isReady {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status":        "not_ready",
-			"lite_build_id": info.LiteBuildID,
-			"timestamp":     time.Now().Format(time.RFC3339),
-		})
-		return
+	report := e.Readiness()
+	statusCode := http.StatusOK
+	if report.Status == ReadinessNotReady || report.Status == ReadinessMaintenance {
+		statusCode = http.StatusServiceUnavailable
 	}
 
-	managerStatus := make(map[string]string)
-	allHealthy := true
-
-	if e.Manager != nil {
-		for _, mgr := range e.Manager.GetAll() {
-			if err := mgr.Health(); err != nil {
-				managerStatus[mgr.ManagerName()] = "unhealthy: " + err.Error()
-				allHealthy = false
-			} else {
-				managerStatus[mgr.ManagerName()] = "ok"
-			}
-		}
-	}
-
-	if !allHealthy {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status":        "degraded",
-			"lite_build_id": info.LiteBuildID,
-			"timestamp":     time.Now().Format(time.RFC3339),
-			"managers":      managerStatus,
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":        "ok",
+	c.JSON(statusCode, gin.H{
+		"status":        report.Status,
 		"lite_build_id": info.LiteBuildID,
 		"timestamp":     time.Now().Format(time.RFC3339),
-		"managers":      managerStatus,
+		"checks":        report.Checks,
+		"managers":      e.managerStatus(report.Checks), // 兼容旧版响应，新代码请使用 checks
 	})
 }
+
+// managerStatus 返回旧版 /api/ready 响应中的 managers 字段：管理器名称 → "ok" 或 "unhealthy: <原因>"
+// 未参与就绪检查的管理器（如 driver 为 none）视为 ok
+func (e *Engine) managerStatus(checks map[string]HealthCheckResult) map[string]string {
+	status := make(map[string]string)
+	if e.Manager == nil {
+		return status
+	}
+	for _, mgr := range e.Manager.GetAll() {
+		name := mgr.ManagerName()
+		result, ok := checks[name]
+		switch {
+		case !ok || result.Status == HealthCheckOK:
+			status[name] = HealthCheckOK
+		case result.Status == HealthCheckUnhealthy:
+			status[name] = "unhealthy: " + result.Error
+		default:
+			status[name] = result.Status
+		}
+	}
+	return status
+}
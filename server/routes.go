@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RouteInfo 已注册路由的描述信息
+type RouteInfo struct {
+	Method      string   `json:"method"`      // HTTP 方法（ANY 展开前的原始写法保留为 ANY）
+	Path        string   `json:"path"`        // 完整路径（含分组前缀）
+	Controller  string   `json:"controller"`  // 控制器名称，系统路由为 Engine
+	Group       string   `json:"group"`       // 路由分组，未分组为空
+	Middlewares []string `json:"middlewares"` // 该路由实际执行的中间件（按执行顺序，global: 为全局中间件，scoped: 为限定作用范围中间件）
+	Admin       bool     `json:"admin"`       // 是否挂载在管理端监听器
+
+	Timeout      time.Duration `json:"timeout,omitempty"`        // 请求处理超时（纳秒），0 表示不限制
+	MaxBodyBytes int64         `json:"max_body_bytes,omitempty"` // 请求体最大字节数，0 表示不限制
+}
+
+// systemRouteController 系统路由在路由表中的控制器名称
+const systemRouteController = "Engine"
+
+// ginAnyMethods gin 的 Any 实际注册的方法
+var ginAnyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodConnect, http.MethodTrace,
+}
+
+// routeRegistry 记录已注册的路由并检测重复注册
+type routeRegistry struct {
+	routes []RouteInfo
+	owners map[string]string // 监听器+方法+路径 -> 控制器名称
+}
+
+func newRouteRegistry() *routeRegistry {
+	return &routeRegistry{owners: make(map[string]string)}
+}
+
+// normalizeRouteMethod 规范化路由方法，与 registerRouteOn 的注册行为一致（未知方法按 GET 注册）
+func normalizeRouteMethod(method string) string {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	switch method {
+	case "GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "ANY":
+		return method
+	default:
+		return "GET"
+	}
+}
+
+// add 登记路由，与已登记的同一监听器上相同方法和路径的路由冲突时返回错误
+func (r *routeRegistry) add(info RouteInfo) error {
+	methods := []string{info.Method}
+	if info.Method == "ANY" {
+		methods = ginAnyMethods
+	}
+
+	scope := "public"
+	if info.Admin {
+		scope = "admin"
+	}
+	for _, method := range methods {
+		if owner, exists := r.owners[scope+" "+method+" "+info.Path]; exists {
+			return fmt.Errorf("duplicate route %s %s: registered by %s and %s",
+				method, info.Path, owner, info.Controller)
+		}
+	}
+	for _, method := range methods {
+		r.owners[scope+" "+method+" "+info.Path] = info.Controller
+	}
+	r.routes = append(r.routes, info)
+	return nil
+}
+
+// registerRouteSafely 注册路由，将 gin 的路由冲突 panic（如通配符冲突）转换为错误
+func registerRouteSafely(router gin.IRoutes, method, path string, handlers ...gin.HandlerFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("register route %s %s failed: %v", method, path, r)
+		}
+	}()
+	registerRouteOn(router, method, path, handlers...)
+	return nil
+}
+
+// Routes 返回已注册的所有路由（需在 Initialize 之后调用），按路径和方法排序
+func (e *Engine) Routes() []RouteInfo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.routeRegistry == nil {
+		return nil
+	}
+	routes := make([]RouteInfo, len(e.routeRegistry.routes))
+	for i, route := range e.routeRegistry.routes {
+		route.Middlewares = append([]string(nil), route.Middlewares...)
+		routes[i] = route
+	}
+	sortRoutes(routes)
+	return routes
+}
+
+// sortRoutes 按路径、方法排序路由
+func sortRoutes(routes []RouteInfo) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+}
+
+// formatRouteTable 将路由格式化为对齐的文本表格
+func formatRouteTable(routes []RouteInfo) string {
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tCONTROLLER\tGROUP\tMIDDLEWARES\tLISTENER")
+	for _, route := range routes {
+		listener := "public"
+		if route.Admin {
+			listener = "admin"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", route.Method, route.Path, route.Controller,
+			dashIfEmpty(route.Group), dashIfEmpty(strings.Join(route.Middlewares, ",")), listener)
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// handleRoutes 路由表端点：返回所有已注册路由
+func (e *Engine) handleRoutes(c *gin.Context) {
+	routes := e.routeRegistry.routes
+	sorted := make([]RouteInfo, len(routes))
+	copy(sorted, routes)
+	sortRoutes(sorted)
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":  len(sorted),
+		"routes": sorted,
+	})
+}
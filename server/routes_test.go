@@ -0,0 +1,147 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+type iTestOrderController interface{ common.IBaseController }
+type iTestOrderAdminController interface{ common.IBaseController }
+
+// TestEngineRoutes 测试路由表记录
+func TestEngineRoutes(t *testing.T) {
+	engine := newRouteTestEngine(t)
+
+	_ = container.RegisterController[iTestAdminController](engine.Controller, &testGroupController{
+		name: "AdminController", group: "/api/admin", route: "/users [GET|POST]",
+	})
+	_ = container.RegisterMiddleware[iTestGlobalMiddleware](engine.Middleware, &testScopedMiddleware{
+		name: "recovery", order: 0,
+	})
+	_ = container.RegisterMiddleware[iTestAuthMiddleware](engine.Middleware, &testScopedMiddleware{
+		name: "auth", order: 300,
+		scope: common.MiddlewareScope{Groups: []string{"/api/admin"}},
+	})
+	if err := engine.registerMiddlewares(); err != nil {
+		t.Fatalf("注册中间件失败: %v", err)
+	}
+	engine.registerSystemRoutes()
+	if err := engine.registerControllers(); err != nil {
+		t.Fatalf("注册控制器失败: %v", err)
+	}
+
+	routes := engine.Routes()
+	if len(routes) != 4 {
+		t.Fatalf("期望 4 条路由（2 条系统路由 + 2 条控制器路由）, 实际 %+v", routes)
+	}
+
+	var controllerRoutes []RouteInfo
+	for _, route := range routes {
+		if route.Controller == "AdminController" {
+			controllerRoutes = append(controllerRoutes, route)
+		}
+	}
+	if len(controllerRoutes) != 2 || controllerRoutes[0].Method != "GET" || controllerRoutes[1].Method != "POST" {
+		t.Fatalf("控制器路由不正确: %+v", controllerRoutes)
+	}
+	route := controllerRoutes[0]
+	if route.Path != "/api/admin/users" || route.Group != "/api/admin" || route.Admin ||
+		strings.Join(route.Middlewares, ",") != "global:recovery,scoped:auth" {
+		t.Errorf("路由信息不正确: %+v", route)
+	}
+	for _, route := range routes {
+		if route.Controller == systemRouteController && strings.Join(route.Middlewares, ",") != "global:recovery" {
+			t.Errorf("系统路由中间件不正确: %+v", route)
+		}
+	}
+
+	table := formatRouteTable(routes)
+	if !strings.Contains(table, "/api/admin/users") || !strings.Contains(table, "global:recovery,scoped:auth") {
+		t.Errorf("路由表缺少控制器路由:\n%s", table)
+	}
+}
+
+// TestEngineRoutes_Duplicate 测试重复路由检测
+func TestEngineRoutes_Duplicate(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   string
+		second  string
+		wantErr string
+	}{
+		{name: "相同方法和路径", first: "/api/orders [GET]", second: "/api/orders [GET]", wantErr: "duplicate route GET /api/orders"},
+		{name: "ANY与具体方法冲突", first: "/api/orders [POST]", second: "/api/orders [ANY]", wantErr: "duplicate route POST /api/orders"},
+		{name: "通配符冲突", first: "/api/orders/:id [GET]", second: "/api/orders/:name [GET]", wantErr: "register route GET /api/orders/:"},
+		{name: "方法不同_不冲突", first: "/api/orders [GET]", second: "/api/orders [POST]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newRouteTestEngine(t)
+			_ = container.RegisterController[iTestOrderController](engine.Controller, &testGroupController{
+				name: "OrderController", route: tt.first,
+			})
+			_ = container.RegisterController[iTestOrderAdminController](engine.Controller, &testGroupController{
+				name: "OrderAdminController", route: tt.second,
+			})
+
+			err := engine.registerControllers()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("未期望的错误: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("期望错误包含 %q, 实际 %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestRoutesEndpoint 测试路由表端点的挂载
+func TestRoutesEndpoint(t *testing.T) {
+	t.Run("启用管理端_挂载到管理端", func(t *testing.T) {
+		engine := newAdminTestEngine(t, true, "release")
+
+		w := httptest.NewRecorder()
+		engine.adminEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/routes", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("期望 200, 实际 %d", w.Code)
+		}
+		var body struct {
+			Count  int         `json:"count"`
+			Routes []RouteInfo `json:"routes"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("解析响应失败: %v", err)
+		}
+		if body.Count != len(body.Routes) || body.Count != len(engine.Routes()) {
+			t.Errorf("路由数量不一致: count=%d, routes=%d", body.Count, len(body.Routes))
+		}
+
+		if code := serveStatus(engine.ginEngine, "/api/routes"); code != http.StatusNotFound {
+			t.Errorf("公共端口期望 404, 实际 %d", code)
+		}
+	})
+
+	t.Run("未启用管理端_release模式不注册", func(t *testing.T) {
+		engine := newAdminTestEngine(t, false, "release")
+		if code := serveStatus(engine.ginEngine, "/api/routes"); code != http.StatusNotFound {
+			t.Errorf("期望 404, 实际 %d", code)
+		}
+	})
+
+	t.Run("未启用管理端_debug模式注册到公共端口", func(t *testing.T) {
+		engine := newAdminTestEngine(t, false, "debug")
+		if code := serveStatus(engine.ginEngine, "/api/routes"); code != http.StatusOK {
+			t.Errorf("期望 200, 实际 %d", code)
+		}
+	})
+}
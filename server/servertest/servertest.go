@@ -0,0 +1,204 @@
+// Package servertest 提供在进程内启动完整 server.Engine 的测试工具
+//
+// 使用内存配置（默认 memory/sqlite 驱动）初始化 Engine，启动各层组件但不监听端口，
+// 通过 Handler 配合 httptest 发送请求，并在 t.Cleanup 中自动停止 Engine：
+//
+//	func TestCreateMessage(t *testing.T) {
+//	    engine, _ := application.NewEngine("")
+//	    srv := servertest.New(t, engine,
+//	        servertest.WithConfig(map[string]any{"app": map[string]any{"name": "test"}}),
+//	        servertest.WithService[services.IMailService](&fakeMailService{}),
+//	    )
+//
+//	    w := srv.Do(httptest.NewRequest(http.MethodPost, "/api/messages", body))
+//	    assert.Equal(t, http.StatusOK, w.Code)
+//	}
+package servertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/server"
+)
+
+// Option 测试服务器选项
+type Option func(*options)
+
+// options 测试服务器配置
+type options struct {
+	config    map[string]any
+	overrides []func(t testing.TB, engine *server.Engine)
+}
+
+// WithConfig 合并配置到默认测试配置之上
+// 同名的 map 递归合并，其余值直接覆盖；多次调用按顺序合并
+func WithConfig(cfg map[string]any) Option {
+	return func(o *options) {
+		o.config = mergeConfig(o.config, cfg)
+	}
+}
+
+// WithManager 用 fake 替换接口 T 对应的内置管理器
+func WithManager[T common.IBaseManager](impl T) Option {
+	return func(o *options) {
+		o.overrides = append(o.overrides, func(t testing.TB, engine *server.Engine) {
+			if err := engine.ApplyOptions(server.WithManager[T](impl)); err != nil {
+				t.Fatalf("servertest: failed to override manager: %v", err)
+			}
+		})
+	}
+}
+
+// WithService 用 fake 替换接口 T 对应的已注册服务
+func WithService[T common.IBaseService](impl T) Option {
+	return func(o *options) {
+		o.overrides = append(o.overrides, func(t testing.TB, engine *server.Engine) {
+			if err := container.ReplaceService[T](engine.Service, impl); err != nil {
+				t.Fatalf("servertest: failed to replace service: %v", err)
+			}
+		})
+	}
+}
+
+// WithRepository 用 fake 替换接口 T 对应的已注册仓储
+func WithRepository[T common.IBaseRepository](impl T) Option {
+	return func(o *options) {
+		o.overrides = append(o.overrides, func(t testing.TB, engine *server.Engine) {
+			if err := container.ReplaceRepository[T](engine.Repository, impl); err != nil {
+				t.Fatalf("servertest: failed to replace repository: %v", err)
+			}
+		})
+	}
+}
+
+// Server 进程内运行的测试服务器
+type Server struct {
+	Engine  *server.Engine
+	Handler http.Handler
+
+	t testing.TB
+}
+
+// New 初始化并在进程内启动 engine，测试结束时自动停止
+// engine 通常由应用生成的 NewEngine 创建，其 BuiltinConfig 会被忽略
+func New(t testing.TB, engine *server.Engine, opts ...Option) *Server {
+	t.Helper()
+
+	o := &options{config: DefaultConfig(t)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := engine.ApplyOptions(server.WithConfigMap(o.config)); err != nil {
+		t.Fatalf("servertest: %v", err)
+	}
+	for _, override := range o.overrides {
+		override(t, engine)
+	}
+
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("servertest: failed to initialize engine: %v", err)
+	}
+	if err := engine.StartInProcess(); err != nil {
+		_ = engine.Stop()
+		t.Fatalf("servertest: failed to start engine: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := engine.Stop(); err != nil {
+			t.Errorf("servertest: failed to stop engine: %v", err)
+		}
+	})
+
+	return &Server{
+		Engine:  engine,
+		Handler: engine.Handler(),
+		t:       t,
+	}
+}
+
+// Do 在进程内处理请求并返回响应记录
+func (s *Server) Do(req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, req)
+	return w
+}
+
+// Get 发送 GET 请求
+func (s *Server) Get(path string) *httptest.ResponseRecorder {
+	return s.Do(httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// NewHTTPServer 基于 Handler 启动 httptest.Server（监听本地随机端口），测试结束时自动关闭
+// 适用于需要真实 HTTP 客户端的场景（如测试 SSE、重定向）
+func (s *Server) NewHTTPServer() *httptest.Server {
+	ts := httptest.NewServer(s.Handler)
+	s.t.Cleanup(ts.Close)
+	return ts
+}
+
+// DefaultConfig 返回默认测试配置
+// 使用内存驱动（缓存、锁、限流、消息队列）和临时目录中的 SQLite 数据库，关闭日志与遥测
+func DefaultConfig(t testing.TB) map[string]any {
+	return map[string]any{
+		"server": map[string]any{
+			"mode": "test",
+			"startup_log": map[string]any{
+				"enabled": false,
+			},
+			"readiness": map[string]any{
+				"startup_timeout": "5s",
+			},
+		},
+		"telemetry": map[string]any{"driver": "none"},
+		"logger":    map[string]any{"driver": "none"},
+		"database": map[string]any{
+			"driver":       "sqlite",
+			"auto_migrate": true,
+			"sqlite_config": map[string]any{
+				"dsn": filepath.Join(t.TempDir(), "test.db"),
+			},
+		},
+		"cache": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"lock": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"limiter": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"mq": map[string]any{
+			"driver":        "memory",
+			"memory_config": map[string]any{},
+		},
+		"scheduler": map[string]any{
+			"driver":      "cron",
+			"cron_config": map[string]any{},
+		},
+	}
+}
+
+// mergeConfig 将 src 递归合并到 dst，返回合并结果
+func mergeConfig(dst, src map[string]any) map[string]any {
+	if dst == nil {
+		dst = map[string]any{}
+	}
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]any)
+		dstMap, dstIsMap := dst[key].(map[string]any)
+		if srcIsMap && dstIsMap {
+			dst[key] = mergeConfig(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+	return dst
+}
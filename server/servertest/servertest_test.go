@@ -0,0 +1,136 @@
+package servertest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/manager/cachemgr"
+	"github.com/lite-lake/litecore-go/server"
+)
+
+type iGreetingService interface {
+	common.IBaseService
+	Greet() string
+}
+
+type greetingService struct {
+	greeting string
+}
+
+func (s *greetingService) ServiceName() string { return "GreetingService" }
+func (s *greetingService) OnStart() error      { return nil }
+func (s *greetingService) OnStop() error       { return nil }
+func (s *greetingService) Greet() string       { return s.greeting }
+
+type iGreetingController interface {
+	common.IBaseController
+}
+
+type greetingController struct {
+	Service  iGreetingService       `inject:""`
+	CacheMgr cachemgr.ICacheManager `inject:""`
+}
+
+func (c *greetingController) ControllerName() string { return "GreetingController" }
+func (c *greetingController) GetRouter() string      { return "/api/greeting [GET]" }
+func (c *greetingController) Handle(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"greeting": c.Service.Greet(), "cache": c.CacheMgr.ManagerName()})
+}
+
+// fakeCacheManager 替换内置缓存管理器的 fake
+type fakeCacheManager struct {
+	cachemgr.ICacheManager
+}
+
+func (m *fakeCacheManager) ManagerName() string { return "FakeCacheManager" }
+func (m *fakeCacheManager) Health() error       { return nil }
+func (m *fakeCacheManager) OnStart() error      { return nil }
+func (m *fakeCacheManager) OnStop() error       { return nil }
+
+// newTestEngine 创建注册了测试组件的 Engine
+func newTestEngine() *server.Engine {
+	entityContainer := container.NewEntityContainer()
+	repositoryContainer := container.NewRepositoryContainer(entityContainer)
+	serviceContainer := container.NewServiceContainer(repositoryContainer)
+	controllerContainer := container.NewControllerContainer(serviceContainer)
+	middlewareContainer := container.NewMiddlewareContainer(serviceContainer)
+
+	_ = container.RegisterService[iGreetingService](serviceContainer, &greetingService{greeting: "hello"})
+	_ = container.RegisterController[iGreetingController](controllerContainer, &greetingController{})
+
+	return server.NewEngine(nil, entityContainer, repositoryContainer, serviceContainer,
+		controllerContainer, middlewareContainer, nil, nil)
+}
+
+func decodeBody(t *testing.T, body []byte) map[string]any {
+	t.Helper()
+	var result map[string]any
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("解析响应失败: %v, body=%s", err, body)
+	}
+	return result
+}
+
+func TestNew(t *testing.T) {
+	t.Run("使用默认配置启动", func(t *testing.T) {
+		srv := New(t, newTestEngine())
+
+		w := srv.Get("/api/greeting")
+		if w.Code != http.StatusOK {
+			t.Fatalf("期望 200, 实际 %d: %s", w.Code, w.Body.String())
+		}
+		if got := decodeBody(t, w.Body.Bytes())["greeting"]; got != "hello" {
+			t.Errorf("期望 hello, 实际 %v", got)
+		}
+
+		if w := srv.Get("/api/ready"); w.Code != http.StatusOK {
+			t.Errorf("期望就绪探针返回 200, 实际 %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("替换服务与管理器", func(t *testing.T) {
+		srv := New(t, newTestEngine(),
+			WithService[iGreetingService](&greetingService{greeting: "fake"}),
+			WithManager[cachemgr.ICacheManager](&fakeCacheManager{}),
+		)
+
+		body := decodeBody(t, srv.Get("/api/greeting").Body.Bytes())
+		if body["greeting"] != "fake" || body["cache"] != "FakeCacheManager" {
+			t.Errorf("期望使用 fake 实现, 实际 %v", body)
+		}
+	})
+
+	t.Run("真实HTTP服务器", func(t *testing.T) {
+		srv := New(t, newTestEngine())
+		ts := srv.NewHTTPServer()
+
+		resp, err := http.Get(ts.URL + "/api/greeting")
+		if err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("期望 200, 实际 %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestMergeConfig(t *testing.T) {
+	merged := mergeConfig(
+		map[string]any{"server": map[string]any{"mode": "test", "port": 8080}, "cache": map[string]any{"driver": "memory"}},
+		map[string]any{"server": map[string]any{"port": 9090}, "cache": "none"},
+	)
+
+	server := merged["server"].(map[string]any)
+	if server["mode"] != "test" || server["port"] != 9090 {
+		t.Errorf("期望递归合并 server, 实际 %v", server)
+	}
+	if merged["cache"] != "none" {
+		t.Errorf("期望非 map 值直接覆盖, 实际 %v", merged["cache"])
+	}
+}
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lite-lake/litecore-go/container"
+	"github.com/lite-lake/litecore-go/logger"
+	"github.com/lite-lake/litecore-go/manager/schedulermgr"
+)
+
+// 关闭阶段名称（按执行顺序）
+const (
+	ShutdownPhaseNotReady   = "not_ready"  // 标记未就绪，/api/ready 返回 503
+	ShutdownPhaseDeregister = "deregister" // 等待负载均衡摘除实例
+	ShutdownPhaseWorkers    = "workers"    // 停止监听器消费和定时器，等待处理中的任务完成
+	ShutdownPhaseHTTPDrain  = "http_drain" // 排空进行中的 HTTP 请求
+	ShutdownPhaseServices   = "services"   // 停止中间件、服务、仓储
+	ShutdownPhaseManagers   = "managers"   // 停止管理器
+)
+
+// ShutdownConfig 关闭阶段配置
+// HTTP 排空阶段的超时沿用 server.shutdown_timeout
+type ShutdownConfig struct {
+	DeregisterDelay time.Duration `yaml:"deregister_delay"` // 标记未就绪后等待负载均衡摘除的时间，默认 0（不等待）
+	WorkerTimeout   time.Duration `yaml:"worker_timeout"`   // 等待监听器/定时器处理中任务完成的超时，默认 10s
+	ServiceTimeout  time.Duration `yaml:"service_timeout"`  // 停止中间件、服务、仓储的超时，默认 10s
+	ManagerTimeout  time.Duration `yaml:"manager_timeout"`  // 停止管理器的超时，默认 10s
+}
+
+// DefaultShutdownConfig 返回默认的关闭阶段配置
+func DefaultShutdownConfig() *ShutdownConfig {
+	return &ShutdownConfig{
+		DeregisterDelay: 0,
+		WorkerTimeout:   10 * time.Second,
+		ServiceTimeout:  10 * time.Second,
+		ManagerTimeout:  10 * time.Second,
+	}
+}
+
+// Validate 验证关闭阶段配置
+func (c *ShutdownConfig) Validate() error {
+	var errs []error
+	if c.DeregisterDelay < 0 {
+		errs = append(errs, fmt.Errorf("server.shutdown.deregister_delay: cannot be negative"))
+	}
+	timeouts := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"worker_timeout", c.WorkerTimeout},
+		{"service_timeout", c.ServiceTimeout},
+		{"manager_timeout", c.ManagerTimeout},
+	}
+	for _, t := range timeouts {
+		if t.value <= 0 {
+			errs = append(errs, fmt.Errorf("server.shutdown.%s: must be greater than 0", t.key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// skippedShutdownPhase 返回因前序阶段 blocker 未结束而跳过的阶段报告
+func skippedShutdownPhase(name, blocker string) ShutdownPhaseReport {
+	return ShutdownPhaseReport{
+		Name:    name,
+		Skipped: true,
+		Errors:  []string{fmt.Sprintf("skipped: phase %s is still running", blocker)},
+	}
+}
+
+// ShutdownPhaseReport 单个关闭阶段的执行结果
+type ShutdownPhaseReport struct {
+	Name      string        `json:"name"`
+	Duration  time.Duration `json:"duration"`
+	Timeout   time.Duration `json:"timeout,omitempty"` // 0 表示该阶段不受超时限制
+	TimedOut  bool          `json:"timed_out"`
+	Abandoned bool          `json:"abandoned,omitempty"` // 超时并经过宽限期后仍未结束，后续阶段被跳过
+	Skipped   bool          `json:"skipped,omitempty"`   // 前序阶段仍未结束，本阶段未执行
+	Errors    []string      `json:"errors,omitempty"`
+}
+
+// ShutdownReport 关闭报告
+type ShutdownReport struct {
+	StartedAt time.Time             `json:"started_at"`
+	Duration  time.Duration         `json:"duration"`
+	Phases    []ShutdownPhaseReport `json:"phases"`
+}
+
+// ErrorCount 返回所有阶段的错误数量（超时计为错误）
+func (r ShutdownReport) ErrorCount() int {
+	count := 0
+	for _, phase := range r.Phases {
+		count += len(phase.Errors)
+	}
+	return count
+}
+
+// Err 汇总所有阶段的错误，无错误时返回 nil
+func (r ShutdownReport) Err() error {
+	var messages []string
+	for _, phase := range r.Phases {
+		for _, msg := range phase.Errors {
+			messages = append(messages, phase.Name+": "+msg)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("shutdown completed with %d error(s): %s", len(messages), strings.Join(messages, "; "))
+}
+
+// ShutdownReport 返回最近一次 Stop 的关闭报告，未关闭过时返回零值
+func (e *Engine) ShutdownReport() ShutdownReport {
+	e.shutdownMu.Lock()
+	defer e.shutdownMu.Unlock()
+	return e.shutdownReport
+}
+
+// shutdownPhaseGrace 阶段超时取消 ctx 后，等待 fn 返回的宽限期
+var shutdownPhaseGrace = 2 * time.Second
+
+// runShutdownPhase 执行关闭阶段
+// fn 在独立 goroutine 中执行，超过 timeout（大于 0 时）后记为超时并取消 fn 收到的 ctx，
+// 再等待 fn 返回最多 shutdownPhaseGrace，避免与下一阶段并发执行；宽限期后仍未返回时标记为 Abandoned
+func runShutdownPhase(name string, timeout time.Duration, fn func(ctx context.Context) []error) ShutdownPhaseReport {
+	start := time.Now()
+	report := ShutdownPhaseReport{Name: name, Timeout: timeout}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	done := make(chan []error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- []error{fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	var errs []error
+	select {
+	case errs = <-done:
+	case <-ctx.Done():
+		report.TimedOut = true
+		cancel()
+		select {
+		case errs = <-done:
+		case <-time.After(shutdownPhaseGrace):
+			report.Abandoned = true
+		}
+		errs = append([]error{fmt.Errorf("timed out after %s", timeout)}, errs...)
+		if report.Abandoned {
+			errs = append(errs, fmt.Errorf("still running after %s grace period", shutdownPhaseGrace))
+		}
+	}
+	for _, err := range errs {
+		report.Errors = append(report.Errors, err.Error())
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+// shutdownWorkers 停止监听器消费和定时器，等待处理中的消息和 OnTick 完成
+func (e *Engine) shutdownWorkers(ctx context.Context) []error {
+	if e.stopConsuming != nil {
+		e.stopConsuming()
+	}
+	errs := e.stopListeners()
+	errs = append(errs, e.stopSchedulers()...)
+
+	if err := e.listenerTasks.wait(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("listener handlers still running: %w", err))
+	}
+	if e.Scheduler != nil && len(e.Scheduler.GetAll()) > 0 {
+		if schedulerMgr, err := container.GetManager[schedulermgr.ISchedulerManager](e.Manager); err == nil {
+			if waiter, ok := schedulerMgr.(schedulermgr.ITickWaiter); ok {
+				if err := waiter.Wait(ctx); err != nil {
+					errs = append(errs, fmt.Errorf("scheduler ticks still running: %w", err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// shutdownHTTP 排空 HTTP 请求，超时后强制关闭连接
+func (e *Engine) shutdownHTTP(ctx context.Context) []error {
+	var errs []error
+	shutdown := func(name string, srv *http.Server) {
+		if srv == nil {
+			return
+		}
+		if err := srv.Shutdown(ctx); err != nil {
+			_ = srv.Close()
+			errs = append(errs, fmt.Errorf("%s shutdown error: %w", name, err))
+		}
+	}
+	shutdown("HTTP server", e.httpServer)
+	shutdown("admin server", e.adminServer)
+	e.listeners = nil
+
+	// 删除 Unix 域套接字文件（已移交给新进程时由新进程负责）
+	if unixSocket := e.serverConfig.UnixSocket; unixSocket.Enabled() && unixSocket.RemoveOnShutdown && !e.handedOff {
+		if err := removeUnixSocket(unixSocket.Path); err != nil {
+			e.logger().Warn("Failed to remove unix socket", "path", unixSocket.Path, "error", err)
+		}
+	}
+	if e.tlsCerts != nil {
+		e.tlsCerts.stop()
+	}
+	return errs
+}
+
+// logShutdownReport 输出关闭报告
+func (e *Engine) logShutdownReport(report ShutdownReport) {
+	for _, phase := range report.Phases {
+		fields := []any{
+			"phase", phase.Name,
+			"duration", phase.Duration.String(),
+		}
+		if phase.Timeout > 0 {
+			fields = append(fields, "timeout", phase.Timeout.String())
+		}
+		if len(phase.Errors) > 0 {
+			fields = append(fields, "timed_out", phase.TimedOut, "errors", strings.Join(phase.Errors, "; "))
+			if phase.Abandoned || phase.Skipped {
+				fields = append(fields, "abandoned", phase.Abandoned, "skipped", phase.Skipped)
+			}
+			e.logger().Warn("Shutdown phase completed with errors", fields...)
+		} else {
+			e.logger().Info("Shutdown phase completed", fields...)
+		}
+	}
+	e.logPhaseEnd(PhaseShutdown, "Shutdown completed",
+		logger.F("error_count", report.ErrorCount()),
+		logger.F("total_duration", report.Duration.String()))
+}
+
+// inflightTracker 跟踪进行中的任务（如监听器消息处理），用于关闭时等待其完成
+type inflightTracker struct {
+	mu      sync.Mutex
+	running int
+	idle    chan struct{} // wait 期间创建，running 归零时关闭
+
+	abortCtx context.Context    // 等待超时后取消，通知仍在执行的任务中止
+	abort    context.CancelFunc // 取消 abortCtx
+}
+
+// newInflightTracker 创建任务跟踪器
+func newInflightTracker() *inflightTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &inflightTracker{abortCtx: ctx, abort: cancel}
+}
+
+// track 登记一个任务，返回任务使用的 ctx 和完成回调
+// 返回的 ctx 不随 parent 取消（停止消费不中断处理中的任务），仅在 wait 超时后取消
+func (t *inflightTracker) track(parent context.Context) (context.Context, func()) {
+	t.mu.Lock()
+	t.running++
+	t.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	stop := context.AfterFunc(t.abortCtx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.running--
+		if t.running == 0 && t.idle != nil {
+			close(t.idle)
+			t.idle = nil
+		}
+	}
+}
+
+// wait 等待所有任务完成；ctx 到期时中止剩余任务并返回 ctx.Err()
+func (t *inflightTracker) wait(ctx context.Context) error {
+	t.mu.Lock()
+	if t.running == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+	idle := t.idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		t.abort()
+		return ctx.Err()
+	}
+}
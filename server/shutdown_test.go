@@ -0,0 +1,295 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+// TestRunShutdownPhase 测试关闭阶段的超时、错误与 panic 处理
+func TestRunShutdownPhase(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		fn            func(ctx context.Context) []error
+		wantTimedOut  bool
+		wantAbandoned bool
+		wantErr       string
+	}{
+		{
+			name:    "正常完成",
+			timeout: time.Second,
+			fn:      func(context.Context) []error { return nil },
+		},
+		{
+			name:    "返回错误",
+			timeout: time.Second,
+			fn: func(context.Context) []error {
+				return []error{errors.New("failed to stop service A")}
+			},
+			wantErr: "failed to stop service A",
+		},
+		{
+			name:    "超时_宽限期内返回",
+			timeout: 20 * time.Millisecond,
+			fn: func(ctx context.Context) []error {
+				<-ctx.Done()
+				return []error{errors.New("aborted")}
+			},
+			wantTimedOut: true,
+			wantErr:      "timed out after 20ms; aborted",
+		},
+		{
+			name:    "超时_宽限期后仍未返回",
+			timeout: 20 * time.Millisecond,
+			fn: func(context.Context) []error {
+				time.Sleep(time.Second)
+				return nil
+			},
+			wantTimedOut:  true,
+			wantAbandoned: true,
+			wantErr:       "still running after 50ms grace period",
+		},
+		{
+			name:    "panic",
+			timeout: time.Second,
+			fn:      func(context.Context) []error { panic("boom") },
+			wantErr: "panic: boom",
+		},
+		{
+			name:    "不限超时",
+			timeout: 0,
+			fn: func(ctx context.Context) []error {
+				if _, ok := ctx.Deadline(); ok {
+					return []error{errors.New("unexpected deadline")}
+				}
+				return nil
+			},
+		},
+	}
+	grace := shutdownPhaseGrace
+	shutdownPhaseGrace = 50 * time.Millisecond
+	defer func() { shutdownPhaseGrace = grace }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := runShutdownPhase("test", tt.timeout, tt.fn)
+			if report.TimedOut != tt.wantTimedOut || report.Abandoned != tt.wantAbandoned {
+				t.Errorf("期望 TimedOut=%v Abandoned=%v, 实际 %v %v", tt.wantTimedOut, tt.wantAbandoned, report.TimedOut, report.Abandoned)
+			}
+			got := strings.Join(report.Errors, "; ")
+			if tt.wantErr == "" && got != "" || !strings.Contains(got, tt.wantErr) {
+				t.Errorf("期望错误包含 %q, 实际 %q", tt.wantErr, got)
+			}
+		})
+	}
+}
+
+// TestInflightTracker 测试等待进行中的任务
+func TestInflightTracker(t *testing.T) {
+	t.Run("无任务时立即返回", func(t *testing.T) {
+		if err := newInflightTracker().wait(context.Background()); err != nil {
+			t.Errorf("期望 nil, 实际 %v", err)
+		}
+	})
+
+	t.Run("等待任务完成_父ctx取消不影响任务", func(t *testing.T) {
+		tracker := newInflightTracker()
+		parent, cancelParent := context.WithCancel(context.Background())
+		taskCtx, done := tracker.track(parent)
+		cancelParent()
+		if taskCtx.Err() != nil {
+			t.Fatal("任务 ctx 不应随父 ctx 取消")
+		}
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			done()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := tracker.wait(ctx); err != nil {
+			t.Errorf("期望任务完成, 实际 %v", err)
+		}
+	})
+
+	t.Run("超时后中止任务", func(t *testing.T) {
+		tracker := newInflightTracker()
+		taskCtx, done := tracker.track(context.Background())
+		defer done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := tracker.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("期望 DeadlineExceeded, 实际 %v", err)
+		}
+		select {
+		case <-taskCtx.Done():
+		case <-time.After(time.Second):
+			t.Error("期望等待超时后任务 ctx 被取消")
+		}
+	})
+}
+
+// TestShutdownConfigValidate 测试关闭阶段配置校验
+func TestShutdownConfigValidate(t *testing.T) {
+	if err := DefaultShutdownConfig().Validate(); err != nil {
+		t.Fatalf("默认配置应通过校验: %v", err)
+	}
+
+	cfg := &ShutdownConfig{DeregisterDelay: -time.Second}
+	err := cfg.Validate()
+	for _, key := range []string{"deregister_delay", "worker_timeout", "service_timeout", "manager_timeout"} {
+		if err == nil || !strings.Contains(err.Error(), "server.shutdown."+key) {
+			t.Errorf("期望错误包含 server.shutdown.%s, 实际 %v", key, err)
+		}
+	}
+}
+
+type iTestSlowStopService interface {
+	common.IBaseService
+}
+
+// testSlowStopService OnStop 耗时较长的测试服务
+type testSlowStopService struct {
+	delay time.Duration
+}
+
+func (s *testSlowStopService) ServiceName() string { return "SlowStopService" }
+func (s *testSlowStopService) OnStart() error      { return nil }
+func (s *testSlowStopService) OnStop() error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+// TestEngineStop_ShutdownReport 测试分阶段关闭与关闭报告
+func TestEngineStop_ShutdownReport(t *testing.T) {
+	engine := newRouteTestEngine(t)
+	engine.Manager = container.NewManagerContainer()
+	engine.serverConfig.Shutdown.ServiceTimeout = 50 * time.Millisecond
+	_ = container.RegisterService[iTestSlowStopService](engine.Service, &testSlowStopService{delay: time.Second})
+	engine.started = true
+	engine.ready.Store(true)
+
+	err := engine.Stop()
+	if err == nil || !strings.Contains(err.Error(), "services: timed out after 50ms") {
+		t.Fatalf("期望 services 阶段超时错误, 实际 %v", err)
+	}
+	if engine.ready.Load() {
+		t.Error("关闭后应标记为未就绪")
+	}
+
+	report := engine.ShutdownReport()
+	wantPhases := []string{
+		ShutdownPhaseNotReady, ShutdownPhaseDeregister, ShutdownPhaseWorkers,
+		ShutdownPhaseHTTPDrain, ShutdownPhaseServices, ShutdownPhaseManagers,
+	}
+	if len(report.Phases) != len(wantPhases) {
+		t.Fatalf("期望 %d 个阶段, 实际 %d", len(wantPhases), len(report.Phases))
+	}
+	for i, name := range wantPhases {
+		phase := report.Phases[i]
+		if phase.Name != name {
+			t.Errorf("阶段 %d: 期望 %s, 实际 %s", i, name, phase.Name)
+		}
+		if timedOut := name == ShutdownPhaseServices; phase.TimedOut != timedOut {
+			t.Errorf("阶段 %s: 期望 TimedOut=%v, 实际 %v", name, timedOut, phase.TimedOut)
+		}
+	}
+	if report.ErrorCount() != 1 {
+		t.Errorf("期望 1 个错误, 实际 %d", report.ErrorCount())
+	}
+}
+
+// TestEngineStop_SkipAfterAbandonedPhase 测试阶段宽限期后仍未结束时跳过后续阶段
+func TestEngineStop_SkipAfterAbandonedPhase(t *testing.T) {
+	grace := shutdownPhaseGrace
+	shutdownPhaseGrace = 20 * time.Millisecond
+	defer func() { shutdownPhaseGrace = grace }()
+
+	engine := newRouteTestEngine(t)
+	engine.Manager = container.NewManagerContainer()
+	engine.serverConfig.Shutdown.ServiceTimeout = 20 * time.Millisecond
+	_ = container.RegisterService[iTestSlowStopService](engine.Service, &testSlowStopService{delay: 500 * time.Millisecond})
+	engine.started = true
+
+	err := engine.Stop()
+	if err == nil || !strings.Contains(err.Error(), "managers: skipped: phase services is still running") {
+		t.Fatalf("期望跳过 managers 阶段, 实际 %v", err)
+	}
+	report := engine.ShutdownReport()
+	services, managers := report.Phases[len(report.Phases)-2], report.Phases[len(report.Phases)-1]
+	if !services.Abandoned || !managers.Skipped || managers.Duration != 0 {
+		t.Errorf("阶段状态不正确: services=%+v managers=%+v", services, managers)
+	}
+}
+
+type iTestTrackedService interface {
+	common.IBaseService
+}
+
+// testTrackedService 记录启动和停止状态的测试服务
+type testTrackedService struct {
+	running atomic.Bool
+}
+
+func (s *testTrackedService) ServiceName() string { return "TrackedService" }
+func (s *testTrackedService) OnStart() error      { s.running.Store(true); return nil }
+func (s *testTrackedService) OnStop() error       { s.running.Store(false); return nil }
+
+// TestEngineStart_RollbackOnReadinessGate 测试启动门控失败时停止已启动的组件
+func TestEngineStart_RollbackOnReadinessGate(t *testing.T) {
+	service := &testTrackedService{}
+	engine := newOptionTestEngine(WithConfigMap(map[string]any{
+		"server": map[string]any{
+			"mode":          "test",
+			"lazy_managers": true,
+			"startup_log":   map[string]any{"enabled": false},
+			"readiness":     map[string]any{"startup_timeout": "100ms", "check_timeout": "50ms"},
+		},
+		"telemetry": map[string]any{"driver": "none"},
+		"logger":    map[string]any{"driver": "none"},
+	}))
+	_ = container.RegisterService[iTestTrackedService](engine.Service, service)
+	if err := engine.Initialize(); err != nil {
+		t.Fatalf("初始化失败: %v", err)
+	}
+	_ = engine.AddHealthCheck(HealthCheck{Name: "payment-gateway", Critical: true, Check: func(context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	err := engine.StartInProcess()
+	if err == nil || !strings.Contains(err.Error(), "readiness gate failed") {
+		t.Fatalf("期望启动门控失败, 实际 %v", err)
+	}
+	if service.running.Load() {
+		t.Error("启动失败后服务应已停止")
+	}
+	if engine.started || engine.ready.Load() {
+		t.Error("启动失败后引擎不应处于已启动或就绪状态")
+	}
+
+	report := engine.ShutdownReport()
+	wantPhases := []string{
+		ShutdownPhaseNotReady, ShutdownPhaseWorkers, ShutdownPhaseHTTPDrain,
+		ShutdownPhaseServices, ShutdownPhaseManagers,
+	}
+	if len(report.Phases) != len(wantPhases) {
+		t.Fatalf("期望回滚执行 %d 个阶段（不等待摘除）, 实际 %+v", len(wantPhases), report.Phases)
+	}
+	for i, name := range wantPhases {
+		if report.Phases[i].Name != name {
+			t.Errorf("阶段 %d: 期望 %s, 实际 %s", i, name, report.Phases[i].Name)
+		}
+	}
+	if report.ErrorCount() != 0 {
+		t.Errorf("回滚不应产生错误, 实际 %+v", report.Phases)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package server
+
+import (
+	"os"
+	"syscall"
+)
+
+// restartSignals 触发平滑重启的信号
+var restartSignals = []os.Signal{syscall.SIGHUP, syscall.SIGUSR2}
+
+// maintenanceSignals 切换维护模式的信号
+var maintenanceSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,41 @@
+//go:build !windows
+
+package server
+
+import (
+	"syscall"
+	"testing"
+)
+
+// TestWatchedSignals 测试按配置捕获平滑重启和维护模式信号
+func TestWatchedSignals(t *testing.T) {
+	tests := []struct {
+		name            string
+		gracefulRestart bool
+		signal          bool
+		wantUSR1        bool
+		wantHUP         bool
+	}{
+		{name: "默认_不捕获SIGUSR1"},
+		{name: "启用维护模式信号", signal: true, wantUSR1: true},
+		{name: "启用平滑重启", gracefulRestart: true, wantHUP: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newRouteTestEngine(t)
+			engine.serverConfig.GracefulRestart = tt.gracefulRestart
+			engine.serverConfig.Maintenance.Signal = tt.signal
+
+			sigs := engine.watchedSignals()
+			if got := containsSignal(sigs, syscall.SIGUSR1); got != tt.wantUSR1 {
+				t.Errorf("期望捕获 SIGUSR1=%v, 实际 %v", tt.wantUSR1, got)
+			}
+			if got := containsSignal(sigs, syscall.SIGHUP); got != tt.wantHUP {
+				t.Errorf("期望捕获 SIGHUP=%v, 实际 %v", tt.wantHUP, got)
+			}
+			if !containsSignal(sigs, syscall.SIGTERM) {
+				t.Error("应始终捕获 SIGTERM")
+			}
+		})
+	}
+}
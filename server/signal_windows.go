@@ -0,0 +1,11 @@
+//go:build windows
+
+package server
+
+import "os"
+
+// restartSignals 触发平滑重启的信号（Windows 不支持监听器文件描述符传递，平滑重启不可用）
+var restartSignals []os.Signal
+
+// maintenanceSignals 切换维护模式的信号（Windows 不支持，使用管理端点切换）
+var maintenanceSignals []os.Signal
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StartupError 启动错误，汇总 Initialize 中校验和依赖注入发现的所有问题
+// （如所有缺失的依赖、所有非法的定时器规则），一次性返回而不是在第一个问题处 panic
+type StartupError struct {
+	Problems []error // 各个问题，按阶段顺序排列
+}
+
+// Error 返回错误信息，每个问题一行
+func (e *StartupError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "startup failed with %d problem(s):", len(e.Problems))
+	for _, p := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(p.Error())
+	}
+	return b.String()
+}
+
+// Unwrap 返回所有问题，支持 errors.Is / errors.As
+func (e *StartupError) Unwrap() []error {
+	return e.Problems
+}
+
+// startupProblems 收集启动问题
+type startupProblems struct {
+	problems []error
+}
+
+// add 展开 errors.Join 合并的错误，逐个添加并加上前缀（同一批问题按信息排序，保证输出稳定）
+func (p *startupProblems) add(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	leaves := flattenErrors(err)
+	sort.SliceStable(leaves, func(i, j int) bool {
+		return leaves[i].Error() < leaves[j].Error()
+	})
+	for _, leaf := range leaves {
+		p.problems = append(p.problems, fmt.Errorf("%s: %w", prefix, leaf))
+	}
+}
+
+// err 无问题时返回 nil，否则返回 *StartupError
+func (p *startupProblems) err() error {
+	if len(p.problems) == 0 {
+		return nil
+	}
+	return &StartupError{Problems: p.problems}
+}
+
+// flattenErrors 递归展开实现 Unwrap() []error 的错误
+func flattenErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var leaves []error
+	for _, e := range joined.Unwrap() {
+		leaves = append(leaves, flattenErrors(e)...)
+	}
+	return leaves
+}
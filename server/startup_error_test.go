@@ -0,0 +1,129 @@
+package server
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+type iTestMissingService interface{ common.IBaseService }
+type iTestOrderService interface{ common.IBaseService }
+type iTestBadRuleScheduler interface{ common.IBaseScheduler }
+type iTestMissingDepController interface{ common.IBaseController }
+
+// testOrderService 依赖未注册服务的测试服务
+type testOrderService struct {
+	Missing iTestMissingService `inject:""`
+}
+
+func (s *testOrderService) ServiceName() string { return "OrderService" }
+func (s *testOrderService) OnStart() error      { return nil }
+func (s *testOrderService) OnStop() error       { return nil }
+
+// testMissingDepController 依赖未注册服务的测试控制器
+type testMissingDepController struct {
+	Missing iTestMissingService `inject:""`
+}
+
+func (c *testMissingDepController) ControllerName() string { return "MissingDepController" }
+func (c *testMissingDepController) GetRouter() string      { return "" }
+func (c *testMissingDepController) Handle(*gin.Context)    {}
+
+// testBadRuleScheduler crontab 规则非法的测试定时器
+type testBadRuleScheduler struct {
+	testTickScheduler
+	name string
+}
+
+func (s *testBadRuleScheduler) SchedulerName() string { return s.name }
+func (s *testBadRuleScheduler) GetRule() string       { return "not a cron rule" }
+
+// TestInitialize_StartupError 测试 Initialize 汇总校验和依赖注入问题
+func TestInitialize_StartupError(t *testing.T) {
+	entityContainer := container.NewEntityContainer()
+	repositoryContainer := container.NewRepositoryContainer(entityContainer)
+	serviceContainer := container.NewServiceContainer(repositoryContainer)
+	controllerContainer := container.NewControllerContainer(serviceContainer)
+	middlewareContainer := container.NewMiddlewareContainer(serviceContainer)
+	schedulerContainer := container.NewSchedulerContainer(serviceContainer)
+
+	_ = container.RegisterService[iTestOrderService](serviceContainer, &testOrderService{})
+	_ = container.RegisterController[iTestMissingDepController](controllerContainer, &testMissingDepController{})
+	_ = container.RegisterScheduler[iTestBadRuleScheduler](schedulerContainer, &testBadRuleScheduler{name: "BadRuleScheduler"})
+
+	engine := NewEngine(nil, entityContainer, repositoryContainer, serviceContainer,
+		controllerContainer, middlewareContainer, nil, schedulerContainer,
+		WithConfigMap(map[string]any{
+			"server":    map[string]any{"mode": "test", "startup_log": map[string]any{"enabled": false}},
+			"telemetry": map[string]any{"driver": "none"},
+			"logger":    map[string]any{"driver": "none"},
+			"database":  map[string]any{"driver": "none"},
+			"cache":     map[string]any{"driver": "none"},
+			"lock":      map[string]any{"enabled": false},
+			"limiter":   map[string]any{"enabled": false},
+			"mq":        map[string]any{"enabled": false},
+			"scheduler": map[string]any{"driver": "cron", "cron_config": map[string]any{"validate_on_startup": true}},
+		}))
+
+	err := engine.Initialize()
+	var startupErr *StartupError
+	if !errors.As(err, &startupErr) {
+		t.Fatalf("期望 StartupError, 实际 %v", err)
+	}
+	if len(startupErr.Problems) != 3 {
+		t.Fatalf("期望 3 个问题, 实际 %d: %v", len(startupErr.Problems), err)
+	}
+
+	wants := []string{
+		"scheduler BadRuleScheduler crontab validation failed",
+		"service inject failed: dependency not found for testOrderService.Missing",
+		"controller inject failed: dependency not found for testMissingDepController.Missing",
+	}
+	for i, want := range wants {
+		if !strings.Contains(startupErr.Problems[i].Error(), want) {
+			t.Errorf("问题 %d: 期望包含 %q, 实际 %q", i, want, startupErr.Problems[i].Error())
+		}
+	}
+
+	var notFound *container.DependencyNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Error("期望可通过 errors.As 取得 DependencyNotFoundError")
+	}
+	if !strings.HasPrefix(err.Error(), "startup failed with 3 problem(s):") {
+		t.Errorf("错误信息不正确: %s", err.Error())
+	}
+}
+
+// TestStartupProblems 测试启动问题收集
+func TestStartupProblems(t *testing.T) {
+	t.Run("无问题_返回nil", func(t *testing.T) {
+		var problems startupProblems
+		problems.add("ignored", nil)
+		if err := problems.err(); err != nil {
+			t.Errorf("期望 nil, 实际 %v", err)
+		}
+	})
+
+	t.Run("展开合并的错误_排序并加前缀", func(t *testing.T) {
+		var problems startupProblems
+		problems.add("service inject failed", errors.Join(
+			errors.New("b"),
+			errors.Join(errors.New("c"), errors.New("a")),
+		))
+		err := problems.err()
+		var startupErr *StartupError
+		if !errors.As(err, &startupErr) || len(startupErr.Problems) != 3 {
+			t.Fatalf("期望 3 个问题, 实际 %v", err)
+		}
+		for i, want := range []string{"a", "b", "c"} {
+			if got := startupErr.Problems[i].Error(); got != "service inject failed: "+want {
+				t.Errorf("问题 %d: 期望 %q, 实际 %q", i, want, got)
+			}
+		}
+	})
+}
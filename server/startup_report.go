@@ -0,0 +1,214 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/logger"
+)
+
+// 启动组件类型
+const (
+	ComponentConfigLoad      = "config_load"      // 读取配置文件
+	ComponentManagerBuild    = "manager_build"    // 构建管理器
+	ComponentManagerStart    = "manager_start"    // Manager.OnStart
+	ComponentMigration       = "migration"        // 数据库自动迁移
+	ComponentRepositoryStart = "repository_start" // Repository.OnStart
+	ComponentServiceStart    = "service_start"    // Service.OnStart
+	ComponentMiddlewareStart = "middleware_start" // Middleware.OnStart
+	ComponentListenerStart   = "listener_start"   // 订阅 Listener 队列
+	ComponentSchedulerStart  = "scheduler_start"  // Scheduler.OnStart
+	ComponentRoutes          = "routes"           // 注册控制器路由
+)
+
+// ComponentTiming 单个组件的启动耗时
+type ComponentTiming struct {
+	Phase    string        `json:"phase"`    // 所属启动阶段
+	Kind     string        `json:"kind"`     // 组件类型
+	Name     string        `json:"name"`     // 组件名称
+	Duration time.Duration `json:"duration"` // 耗时（纳秒）
+	Slow     bool          `json:"slow"`     // 是否超过 startup_log.slow_threshold
+}
+
+// PhaseTiming 启动阶段耗时（同一阶段多次进入时累计）
+type PhaseTiming struct {
+	Phase    string        `json:"phase"`
+	Duration time.Duration `json:"duration"` // 耗时（纳秒）
+}
+
+// StartupReport 启动耗时报告
+type StartupReport struct {
+	StartedAt     time.Time         `json:"started_at"`               // Initialize 开始时间
+	Duration      time.Duration     `json:"duration"`                 // Initialize 开始至就绪的总耗时（纳秒），未就绪时为 0
+	Completed     bool              `json:"completed"`                // 是否已完成启动
+	SlowThreshold time.Duration     `json:"slow_threshold,omitempty"` // 慢组件阈值（纳秒），0 表示不检测
+	Phases        []PhaseTiming     `json:"phases"`                   // 各阶段耗时，按阶段顺序
+	Components    []ComponentTiming `json:"components"`               // 各组件耗时，按记录顺序
+}
+
+// Slowest 返回耗时最长的 n 个组件
+func (r StartupReport) Slowest(n int) []ComponentTiming {
+	sorted := make([]ComponentTiming, len(r.Components))
+	copy(sorted, r.Components)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+	if n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// SlowComponents 返回超过阈值的组件
+func (r StartupReport) SlowComponents() []ComponentTiming {
+	var slow []ComponentTiming
+	for _, c := range r.Components {
+		if c.Slow {
+			slow = append(slow, c)
+		}
+	}
+	return slow
+}
+
+// startupRecorder 记录启动耗时，独立加锁，启动过程中也可读取
+type startupRecorder struct {
+	mu         sync.Mutex
+	startedAt  time.Time
+	threshold  time.Duration
+	duration   time.Duration
+	completed  bool
+	phases     map[StartupPhase]time.Duration
+	components []ComponentTiming
+}
+
+func newStartupRecorder() *startupRecorder {
+	return &startupRecorder{
+		startedAt: time.Now(),
+		phases:    make(map[StartupPhase]time.Duration),
+	}
+}
+
+// setThreshold 设置慢组件阈值（读取 server 配置后调用）
+func (r *startupRecorder) setThreshold(threshold time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.threshold = threshold
+	for i := range r.components {
+		r.components[i].Slow = threshold > 0 && r.components[i].Duration > threshold
+	}
+}
+
+// addPhase 累计阶段耗时
+func (r *startupRecorder) addPhase(phase StartupPhase, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phases[phase] += d
+}
+
+// addComponent 记录组件耗时，返回是否超过阈值
+func (r *startupRecorder) addComponent(phase StartupPhase, kind, name string, d time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slow := r.threshold > 0 && d > r.threshold
+	r.components = append(r.components, ComponentTiming{
+		Phase:    phase.String(),
+		Kind:     kind,
+		Name:     name,
+		Duration: d,
+		Slow:     slow,
+	})
+	return slow
+}
+
+// complete 标记启动完成，记录总耗时
+func (r *startupRecorder) complete() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.duration = time.Since(r.startedAt)
+	r.completed = true
+	return r.duration
+}
+
+// report 返回启动报告副本
+func (r *startupRecorder) report() StartupReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := StartupReport{
+		StartedAt:     r.startedAt,
+		Duration:      r.duration,
+		Completed:     r.completed,
+		SlowThreshold: r.threshold,
+		Phases:        make([]PhaseTiming, 0, len(r.phases)),
+		Components:    append([]ComponentTiming(nil), r.components...),
+	}
+	for phase := PhaseConfig; phase <= PhaseStartup; phase++ {
+		if d, ok := r.phases[phase]; ok {
+			report.Phases = append(report.Phases, PhaseTiming{Phase: phase.String(), Duration: d})
+		}
+	}
+	return report
+}
+
+// StartupReport 返回启动耗时报告（Initialize 之后可用，启动完成前为部分结果）
+func (e *Engine) StartupReport() StartupReport {
+	if e.startup == nil {
+		return StartupReport{}
+	}
+	return e.startup.report()
+}
+
+// recordComponent 记录组件耗时，超过阈值时输出警告
+func (e *Engine) recordComponent(phase StartupPhase, kind, name string, d time.Duration) {
+	if e.startup == nil {
+		return
+	}
+	if e.startup.addComponent(phase, kind, name, d) {
+		e.getLogger().Warn("Slow startup component",
+			logger.F("kind", kind),
+			logger.F("name", name),
+			logger.F("duration", d.String()),
+			logger.F("threshold", e.startupLogConfig.SlowThreshold.String()))
+	}
+}
+
+// timeComponent 执行 fn 并记录耗时（失败时同样记录）
+func (e *Engine) timeComponent(phase StartupPhase, kind, name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	e.recordComponent(phase, kind, name, time.Since(start))
+	return err
+}
+
+// completeStartup 标记启动完成并输出最慢的组件
+func (e *Engine) completeStartup() time.Duration {
+	if e.startup == nil {
+		return 0
+	}
+	total := e.startup.complete()
+	report := e.startup.report()
+	if slow := report.SlowComponents(); len(slow) > 0 {
+		e.getLogger().Warn("Startup has slow components",
+			logger.F("count", len(slow)),
+			logger.F("threshold", report.SlowThreshold.String()))
+	}
+	for _, c := range report.Slowest(startupReportTopN) {
+		e.logStartup(PhaseStartup, "Startup timing",
+			logger.F("kind", c.Kind),
+			logger.F("name", c.Name),
+			logger.F("duration", c.Duration.String()))
+	}
+	return total
+}
+
+// startupReportTopN 启动完成时输出的最慢组件数量
+const startupReportTopN = 5
+
+// handleStartupReport 启动报告端点：返回启动耗时报告
+func (e *Engine) handleStartupReport(c *gin.Context) {
+	c.JSON(http.StatusOK, e.StartupReport())
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/container"
+)
+
+type iTestSlowStartService interface {
+	common.IBaseService
+}
+
+// testSlowStartService OnStart 耗时较长的测试服务
+type testSlowStartService struct {
+	delay time.Duration
+}
+
+func (s *testSlowStartService) ServiceName() string { return "SlowStartService" }
+func (s *testSlowStartService) OnStart() error {
+	time.Sleep(s.delay)
+	return nil
+}
+func (s *testSlowStartService) OnStop() error { return nil }
+
+// TestStartupRecorder 测试启动耗时记录
+func TestStartupRecorder(t *testing.T) {
+	t.Run("阈值_标记慢组件", func(t *testing.T) {
+		r := newStartupRecorder()
+		r.setThreshold(100 * time.Millisecond)
+		if r.addComponent(PhaseStartup, ComponentServiceStart, "Fast", 10*time.Millisecond) {
+			t.Error("未超过阈值的组件不应标记为慢组件")
+		}
+		if !r.addComponent(PhaseStartup, ComponentServiceStart, "Slow", 200*time.Millisecond) {
+			t.Error("超过阈值的组件应标记为慢组件")
+		}
+		slow := r.report().SlowComponents()
+		if len(slow) != 1 || slow[0].Name != "Slow" {
+			t.Errorf("慢组件不正确: %+v", slow)
+		}
+	})
+
+	t.Run("设置阈值前记录的组件_重新判定", func(t *testing.T) {
+		r := newStartupRecorder()
+		r.addComponent(PhaseManagers, ComponentManagerBuild, "DatabaseManager", 2*time.Second)
+		r.setThreshold(time.Second)
+		if slow := r.report().SlowComponents(); len(slow) != 1 {
+			t.Errorf("期望 1 个慢组件, 实际 %+v", slow)
+		}
+	})
+
+	t.Run("阈值为0_不检测", func(t *testing.T) {
+		r := newStartupRecorder()
+		r.setThreshold(0)
+		if r.addComponent(PhaseStartup, ComponentServiceStart, "Slow", time.Hour) {
+			t.Error("阈值为 0 时不应标记慢组件")
+		}
+	})
+
+	t.Run("阶段耗时_累计并按阶段排序", func(t *testing.T) {
+		r := newStartupRecorder()
+		r.addPhase(PhaseStartup, 10*time.Millisecond)
+		r.addPhase(PhaseRouter, 5*time.Millisecond)
+		r.addPhase(PhaseStartup, 20*time.Millisecond)
+		phases := r.report().Phases
+		if len(phases) != 2 || phases[0].Phase != PhaseRouter.String() || phases[1].Duration != 30*time.Millisecond {
+			t.Errorf("阶段耗时不正确: %+v", phases)
+		}
+	})
+
+	t.Run("Slowest_按耗时降序截取", func(t *testing.T) {
+		report := StartupReport{Components: []ComponentTiming{
+			{Name: "a", Duration: 1}, {Name: "b", Duration: 3}, {Name: "c", Duration: 2},
+		}}
+		top := report.Slowest(2)
+		if len(top) != 2 || top[0].Name != "b" || top[1].Name != "c" {
+			t.Errorf("Slowest 结果不正确: %+v", top)
+		}
+		if len(report.Slowest(10)) != 3 {
+			t.Error("n 超过组件数时应返回全部组件")
+		}
+	})
+}
+
+// TestEngineStartupReport 测试引擎启动时记录各组件耗时
+func TestEngineStartupReport(t *testing.T) {
+	engine := newRouteTestEngine(t)
+	engine.Manager = container.NewManagerContainer()
+	engine.startup = newStartupRecorder()
+	engine.startup.setThreshold(20 * time.Millisecond)
+	_ = container.RegisterService[iTestSlowStartService](engine.Service, &testSlowStartService{delay: 50 * time.Millisecond})
+
+	if err := engine.startComponents(); err != nil {
+		t.Fatalf("启动组件失败: %v", err)
+	}
+	if report := engine.StartupReport(); report.Completed || report.Duration != 0 {
+		t.Errorf("完成启动前不应标记为已完成: %+v", report)
+	}
+	total := engine.completeStartup()
+
+	report := engine.StartupReport()
+	if !report.Completed || report.Duration != total || total <= 0 {
+		t.Errorf("启动报告未完成: %+v", report)
+	}
+	slow := report.SlowComponents()
+	if len(slow) != 1 || slow[0].Kind != ComponentServiceStart || slow[0].Name != "SlowStartService" {
+		t.Fatalf("期望 SlowStartService 为慢组件, 实际 %+v", slow)
+	}
+	if slow[0].Duration < 50*time.Millisecond || slow[0].Phase != PhaseStartup.String() {
+		t.Errorf("组件耗时记录不正确: %+v", slow[0])
+	}
+	if len(report.Phases) != 1 || report.Phases[0].Duration < 50*time.Millisecond {
+		t.Errorf("阶段耗时记录不正确: %+v", report.Phases)
+	}
+}
+
+// TestStartupReportEndpoint 测试启动报告端点
+func TestStartupReportEndpoint(t *testing.T) {
+	t.Run("debug模式_返回报告", func(t *testing.T) {
+		engine := newRouteTestEngine(t)
+		engine.serverConfig.Mode = "debug"
+		engine.startup = newStartupRecorder()
+		engine.startup.addComponent(PhaseRouter, ComponentRoutes, "controllers", time.Millisecond)
+		engine.registerSystemRoutes()
+
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/startup", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("期望 200, 实际 %d", w.Code)
+		}
+		var report StartupReport
+		if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+			t.Fatalf("解析响应失败: %v", err)
+		}
+		if len(report.Components) != 1 || report.Components[0].Kind != ComponentRoutes {
+			t.Errorf("报告内容不正确: %+v", report)
+		}
+	})
+
+	t.Run("release模式_不注册", func(t *testing.T) {
+		engine := newRouteTestEngine(t)
+		engine.startup = newStartupRecorder()
+		engine.registerSystemRoutes()
+
+		w := httptest.NewRecorder()
+		engine.ginEngine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/startup", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("期望 404, 实际 %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,333 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+	"github.com/lite-lake/litecore-go/logger"
+)
+
+// TLSConfig HTTPS/mTLS 配置
+type TLSConfig struct {
+	Enabled        bool          `yaml:"enabled"`         // 是否启用 TLS
+	CertFile       string        `yaml:"cert_file"`       // 服务端证书文件（PEM）
+	KeyFile        string        `yaml:"key_file"`        // 服务端私钥文件（PEM）
+	MinVersion     string        `yaml:"min_version"`     // 最低 TLS 版本：1.0/1.1/1.2/1.3，默认 1.2
+	CipherSuites   []string      `yaml:"cipher_suites"`   // 允许的密码套件名称（仅作用于 TLS 1.2 及以下），为空使用 Go 默认值
+	ClientCAFile   string        `yaml:"client_ca_file"`  // 校验客户端证书的 CA 文件（PEM），配置后启用 mTLS
+	ClientAuth     string        `yaml:"client_auth"`     // 客户端认证模式：none/request/require/verify_if_given/require_and_verify
+	ReloadInterval time.Duration `yaml:"reload_interval"` // 证书文件变更检测间隔，默认 30s，0 表示不自动重载
+}
+
+// DefaultTLSConfig 返回默认的 TLS 配置
+func DefaultTLSConfig() *TLSConfig {
+	return &TLSConfig{
+		Enabled:        false,
+		MinVersion:     "1.2",
+		ReloadInterval: 30 * time.Second,
+	}
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var tlsClientAuthTypes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify_if_given":    tls.VerifyClientCertIfGiven,
+	"require_and_verify": tls.RequireAndVerifyClientCert,
+}
+
+// Validate 验证 TLS 配置
+func (c *TLSConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("server.tls: cert_file and key_file are required when tls is enabled")
+	}
+	if _, err := c.minVersion(); err != nil {
+		return err
+	}
+	if _, err := c.cipherSuites(); err != nil {
+		return err
+	}
+	clientAuth, err := c.clientAuth()
+	if err != nil {
+		return err
+	}
+	if clientAuth >= tls.VerifyClientCertIfGiven && c.ClientCAFile == "" {
+		return fmt.Errorf("server.tls.client_ca_file: required when client_auth is %s", c.ClientAuth)
+	}
+	if c.ReloadInterval < 0 {
+		return fmt.Errorf("server.tls.reload_interval: cannot be negative")
+	}
+	return nil
+}
+
+// minVersion 解析最低 TLS 版本
+func (c *TLSConfig) minVersion() (uint16, error) {
+	if c.MinVersion == "" {
+		return tls.VersionTLS12, nil
+	}
+	version, ok := tlsVersions[strings.TrimSpace(c.MinVersion)]
+	if !ok {
+		return 0, fmt.Errorf("server.tls.min_version: unsupported value %q (must be 1.0, 1.1, 1.2 or 1.3)", c.MinVersion)
+	}
+	return version, nil
+}
+
+// cipherSuites 将密码套件名称解析为 ID
+func (c *TLSConfig) cipherSuites() ([]uint16, error) {
+	if len(c.CipherSuites) == 0 {
+		return nil, nil
+	}
+
+	available := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		available[suite.Name] = suite.ID
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		available[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(c.CipherSuites))
+	for _, name := range c.CipherSuites {
+		id, ok := available[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("server.tls.cipher_suites: unsupported cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// clientAuth 解析客户端认证模式；未配置时，有 client_ca_file 则要求并校验客户端证书
+func (c *TLSConfig) clientAuth() (tls.ClientAuthType, error) {
+	mode := strings.ToLower(strings.TrimSpace(c.ClientAuth))
+	if mode == "" {
+		if c.ClientCAFile != "" {
+			return tls.RequireAndVerifyClientCert, nil
+		}
+		return tls.NoClientCert, nil
+	}
+	clientAuth, ok := tlsClientAuthTypes[mode]
+	if !ok {
+		return 0, fmt.Errorf("server.tls.client_auth: unsupported value %q", c.ClientAuth)
+	}
+	return clientAuth, nil
+}
+
+// certReloader 证书热加载器
+// 定期检测证书、私钥和客户端 CA 文件的修改时间，变更后重新加载，新连接立即使用新证书
+type certReloader struct {
+	cfg        *TLSConfig
+	baseConfig *tls.Config
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newCertReloader 创建证书热加载器并完成首次加载
+// protocols 为 HTTP 服务器启用的协议，用于设置 ALPN 协商的协议列表
+func newCertReloader(cfg *TLSConfig, protocols *http.Protocols) (*certReloader, error) {
+	minVersion, err := cfg.minVersion()
+	if err != nil {
+		return nil, err
+	}
+	cipherSuites, err := cfg.cipherSuites()
+	if err != nil {
+		return nil, err
+	}
+	clientAuth, err := cfg.clientAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &certReloader{
+		cfg:      cfg,
+		modTimes: make(map[string]time.Time),
+		stopCh:   make(chan struct{}),
+	}
+	r.baseConfig = &tls.Config{
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+		ClientAuth:     clientAuth,
+		NextProtos:     alpnProtocols(protocols),
+		GetCertificate: r.GetCertificate,
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// alpnProtocols 返回 ALPN 协商的协议列表
+// GetConfigForClient 返回的配置会整体替换 http.Server 填充过 NextProtos 的配置，因此需在基础配置中显式设置
+func alpnProtocols(protocols *http.Protocols) []string {
+	var nextProtos []string
+	if protocols.HTTP2() {
+		nextProtos = append(nextProtos, "h2")
+	}
+	if protocols.HTTP1() {
+		nextProtos = append(nextProtos, "http/1.1")
+	}
+	return nextProtos
+}
+
+// TLSConfig 返回服务端 tls.Config
+// 配置了客户端 CA 时通过 GetConfigForClient 为每个连接提供最新的 CA 池
+func (r *certReloader) TLSConfig() *tls.Config {
+	cfg := r.baseConfig.Clone()
+	if r.cfg.ClientCAFile != "" {
+		cfg.GetConfigForClient = r.GetConfigForClient
+	}
+	return cfg
+}
+
+// GetCertificate 返回当前服务端证书
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// GetConfigForClient 返回携带最新客户端 CA 池的连接配置
+func (r *certReloader) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	cfg := r.baseConfig.Clone()
+	r.mu.RLock()
+	cfg.ClientCAs = r.clientCAs
+	r.mu.RUnlock()
+	return cfg, nil
+}
+
+// load 加载证书和客户端 CA
+func (r *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read tls client ca file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no valid certificate found in tls client ca file %s", r.cfg.ClientCAFile)
+		}
+	}
+
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.watchedFiles() {
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+// watchedFiles 返回需要检测变更的文件
+func (r *certReloader) watchedFiles() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// reloadIfChanged 检测文件修改时间，变更时重新加载
+// 加载失败时保留旧证书并返回错误
+func (r *certReloader) reloadIfChanged() (bool, error) {
+	r.mu.RLock()
+	changed := false
+	for _, file := range r.watchedFiles() {
+		info, err := os.Stat(file)
+		if err != nil {
+			r.mu.RUnlock()
+			return false, fmt.Errorf("failed to stat %s: %w", file, err)
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			changed = true
+			break
+		}
+	}
+	r.mu.RUnlock()
+
+	if !changed {
+		return false, nil
+	}
+	if err := r.load(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// watch 按间隔检测证书变更，直到 stop 被调用
+func (r *certReloader) watch(log func() logger.ILogger) {
+	if r.cfg.ReloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			reloaded, err := r.reloadIfChanged()
+			if err != nil {
+				log().Error("Failed to reload TLS certificate, keeping previous one", "error", err)
+				continue
+			}
+			if reloaded {
+				log().Info("TLS certificate reloaded", "cert_file", r.cfg.CertFile)
+			}
+		}
+	}
+}
+
+// stop 停止证书变更检测
+func (r *certReloader) stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// clientCertIdentityHandler 将已校验的客户端证书身份写入 gin 上下文
+func clientCertIdentityHandler(c *gin.Context) {
+	if state := c.Request.TLS; state != nil && len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+		c.Set(common.ContextKeyClientCertIdentity, common.NewClientCertIdentity(state.VerifiedChains[0][0]))
+	}
+	c.Next()
+}
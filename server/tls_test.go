@@ -0,0 +1,349 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lite-lake/litecore-go/common"
+)
+
+// testCert 测试用证书
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert 生成测试证书；parent 为 nil 时生成自签名 CA
+func newTestCert(t *testing.T, cn string, serial int64, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成私钥失败: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"litecore"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{usage}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("生成证书失败: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("解析证书失败: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeTestCert 将证书和私钥写入目录
+func writeTestCert(t *testing.T, dir, name string, cert *testCert) (string, string) {
+	t.Helper()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, cert.certPEM, 0600); err != nil {
+		t.Fatalf("写入证书失败: %v", err)
+	}
+	if err := os.WriteFile(keyFile, cert.keyPEM, 0600); err != nil {
+		t.Fatalf("写入私钥失败: %v", err)
+	}
+	return certFile, keyFile
+}
+
+// decodeTLSConfig 通过 server 配置段解码 TLS 配置
+func decodeTLSConfig(raw map[string]any) (*TLSConfig, error) {
+	config := defaultServerConfig()
+	section := map[string]any{}
+	if raw != nil {
+		section["tls"] = raw
+	}
+	if err := config.decode(section); err != nil {
+		return nil, err
+	}
+	return config.TLS, nil
+}
+
+// TestDecodeTLSConfig 测试 TLS 配置解析
+func TestDecodeTLSConfig(t *testing.T) {
+	t.Run("未配置_使用默认值", func(t *testing.T) {
+		cfg, err := decodeTLSConfig(nil)
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cfg.Enabled || cfg.MinVersion != "1.2" || cfg.ReloadInterval != 30*time.Second {
+			t.Errorf("默认配置不正确: %+v", cfg)
+		}
+	})
+
+	t.Run("完整配置_正确解析", func(t *testing.T) {
+		cfg, err := decodeTLSConfig(map[string]any{
+			"enabled":         true,
+			"cert_file":       "server.crt",
+			"key_file":        "server.key",
+			"min_version":     "1.3",
+			"cipher_suites":   []any{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			"client_ca_file":  "ca.crt",
+			"reload_interval": "5s",
+		})
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cfg.MinVersion != "1.3" || len(cfg.CipherSuites) != 1 || cfg.ReloadInterval != 5*time.Second {
+			t.Errorf("解析结果不正确: %+v", cfg)
+		}
+		if clientAuth, _ := cfg.clientAuth(); clientAuth != tls.RequireAndVerifyClientCert {
+			t.Errorf("配置 client_ca_file 时期望 require_and_verify, 实际 %v", clientAuth)
+		}
+	})
+
+	t.Run("未加引号的版本号_按字符串解析", func(t *testing.T) {
+		for raw, want := range map[any]string{1.2: "1.2", 1.0: "1.0", 1.3: "1.3"} {
+			cfg, err := decodeTLSConfig(map[string]any{"enabled": true, "cert_file": "a", "key_file": "b", "min_version": raw})
+			if err != nil {
+				t.Fatalf("min_version %v: 未期望的错误: %v", raw, err)
+			}
+			if cfg.MinVersion != want {
+				t.Errorf("min_version %v: 期望 %q, 实际 %q", raw, want, cfg.MinVersion)
+			}
+		}
+	})
+
+	t.Run("非法配置_返回错误", func(t *testing.T) {
+		cases := map[string]map[string]any{
+			"缺少证书":   {"enabled": true},
+			"非法版本":   {"enabled": true, "cert_file": "a", "key_file": "b", "min_version": "1.4"},
+			"非法密码套件": {"enabled": true, "cert_file": "a", "key_file": "b", "cipher_suites": []any{"BAD"}},
+			"非法认证模式": {"enabled": true, "cert_file": "a", "key_file": "b", "client_auth": "always"},
+			"校验缺少CA": {"enabled": true, "cert_file": "a", "key_file": "b", "client_auth": "require_and_verify"},
+			"非法间隔":   {"enabled": true, "cert_file": "a", "key_file": "b", "reload_interval": "abc"},
+		}
+		for name, cfg := range cases {
+			if _, err := decodeTLSConfig(cfg); err == nil {
+				t.Errorf("%s: 期望返回错误", name)
+			}
+		}
+	})
+}
+
+// TestCertReloader_ReloadIfChanged 测试证书文件变更后热加载
+func TestCertReloader_ReloadIfChanged(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", 1, nil, 0)
+	certFile, keyFile := writeTestCert(t, dir, "server", newTestCert(t, "server", 2, ca, x509.ExtKeyUsageServerAuth))
+
+	reloader, err := newCertReloader(&TLSConfig{Enabled: true, CertFile: certFile, KeyFile: keyFile}, defaultServerConfig().Protocols(true))
+	if err != nil {
+		t.Fatalf("创建证书加载器失败: %v", err)
+	}
+
+	reloaded, err := reloader.reloadIfChanged()
+	if err != nil || reloaded {
+		t.Fatalf("文件未变更时不应重载: reloaded=%v, err=%v", reloaded, err)
+	}
+
+	writeTestCert(t, dir, "server", newTestCert(t, "server", 3, ca, x509.ExtKeyUsageServerAuth))
+	future := time.Now().Add(time.Minute)
+	for _, file := range []string{certFile, keyFile} {
+		if err := os.Chtimes(file, future, future); err != nil {
+			t.Fatalf("修改文件时间失败: %v", err)
+		}
+	}
+
+	reloaded, err = reloader.reloadIfChanged()
+	if err != nil || !reloaded {
+		t.Fatalf("文件变更后期望重载: reloaded=%v, err=%v", reloaded, err)
+	}
+	cert, _ := reloader.GetCertificate(nil)
+	if cert.Leaf == nil || cert.Leaf.SerialNumber.Int64() != 3 {
+		t.Errorf("期望加载新证书")
+	}
+
+	// 写入无效证书时保留旧证书
+	if err := os.WriteFile(certFile, []byte("invalid"), 0600); err != nil {
+		t.Fatalf("写入证书失败: %v", err)
+	}
+	future = future.Add(time.Minute)
+	if err := os.Chtimes(certFile, future, future); err != nil {
+		t.Fatalf("修改文件时间失败: %v", err)
+	}
+	if _, err := reloader.reloadIfChanged(); err == nil {
+		t.Errorf("无效证书期望返回错误")
+	}
+	if current, _ := reloader.GetCertificate(nil); current != cert {
+		t.Errorf("加载失败时期望保留旧证书")
+	}
+}
+
+// TestMutualTLS_ClientCertIdentity 测试 mTLS 校验客户端证书并将身份写入 gin 上下文
+func TestMutualTLS_ClientCertIdentity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", 1, nil, 0)
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caFile, ca.certPEM, 0600); err != nil {
+		t.Fatalf("写入 CA 失败: %v", err)
+	}
+	certFile, keyFile := writeTestCert(t, dir, "server", newTestCert(t, "localhost", 2, ca, x509.ExtKeyUsageServerAuth))
+	client := newTestCert(t, "order-service", 3, ca, x509.ExtKeyUsageClientAuth)
+
+	reloader, err := newCertReloader(&TLSConfig{
+		Enabled:      true,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		MinVersion:   "1.2",
+	}, defaultServerConfig().Protocols(true))
+	if err != nil {
+		t.Fatalf("创建证书加载器失败: %v", err)
+	}
+
+	router := gin.New()
+	router.Use(clientCertIdentityHandler)
+	router.GET("/whoami", func(c *gin.Context) {
+		identity, ok := common.GetClientCertIdentity(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.JSON(http.StatusOK, identity)
+	})
+
+	server := httptest.NewUnstartedServer(router)
+	server.TLS = reloader.TLSConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+		}}}
+	}
+
+	t.Run("携带客户端证书_返回身份", func(t *testing.T) {
+		clientCert := tls.Certificate{Certificate: [][]byte{client.cert.Raw}, PrivateKey: client.key}
+		resp, err := newClient(clientCert).Get(server.URL + "/whoami")
+		if err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("期望状态码 200, 实际 %d", resp.StatusCode)
+		}
+		var identity common.ClientCertIdentity
+		if err := json.NewDecoder(resp.Body).Decode(&identity); err != nil {
+			t.Fatalf("解析响应失败: %v", err)
+		}
+		if identity.CommonName != "order-service" || identity.SerialNumber != "3" {
+			t.Errorf("身份信息不正确: %+v", identity)
+		}
+	})
+
+	t.Run("未携带客户端证书_握手失败", func(t *testing.T) {
+		resp, err := newClient().Get(server.URL + "/whoami")
+		if err == nil {
+			resp.Body.Close()
+			t.Errorf("期望握手失败")
+		}
+	})
+}
+
+// TestMutualTLS_NegotiateHTTP2 测试 mTLS 连接仍通过 ALPN 协商 HTTP/2
+func TestMutualTLS_NegotiateHTTP2(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", 1, nil, 0)
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caFile, ca.certPEM, 0600); err != nil {
+		t.Fatalf("写入 CA 失败: %v", err)
+	}
+	certFile, keyFile := writeTestCert(t, dir, "server", newTestCert(t, "localhost", 2, ca, x509.ExtKeyUsageServerAuth))
+	client := newTestCert(t, "order-service", 3, ca, x509.ExtKeyUsageClientAuth)
+
+	cfg := defaultServerConfig()
+	reloader, err := newCertReloader(&TLSConfig{
+		Enabled:      true,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+	}, cfg.Protocols(true))
+	if err != nil {
+		t.Fatalf("创建证书加载器失败: %v", err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	srv := &http.Server{
+		Handler:   http.NotFoundHandler(),
+		Protocols: cfg.Protocols(true),
+		TLSConfig: reloader.TLSConfig(),
+	}
+	go func() { _ = srv.ServeTLS(ln, "", "") }()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{{Certificate: [][]byte{client.cert.Raw}, PrivateKey: client.key}},
+		NextProtos:   []string{"h2", "http/1.1"},
+	})
+	if err != nil {
+		t.Fatalf("mTLS 握手失败: %v", err)
+	}
+	defer conn.Close()
+
+	if proto := conn.ConnectionState().NegotiatedProtocol; proto != "h2" {
+		t.Errorf("期望协商 h2, 实际 %q", proto)
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// UnixSocketConfig Unix 域套接字监听配置
+// 配置 path 后 HTTP 服务器监听该套接字文件，替代 host:port
+type UnixSocketConfig struct {
+	Path             string      `yaml:"path"`               // 套接字文件路径，为空表示不启用
+	FileMode         os.FileMode `yaml:"file_mode"`          // 套接字文件权限，默认 0660
+	RemoveOnShutdown bool        `yaml:"remove_on_shutdown"` // 停止时是否删除套接字文件，默认 true
+}
+
+// DefaultUnixSocketConfig 返回默认的 Unix 域套接字配置
+func DefaultUnixSocketConfig() *UnixSocketConfig {
+	return &UnixSocketConfig{
+		FileMode:         0660,
+		RemoveOnShutdown: true,
+	}
+}
+
+// Enabled 是否启用 Unix 域套接字监听
+func (c *UnixSocketConfig) Enabled() bool {
+	return c != nil && c.Path != ""
+}
+
+// Validate 验证 Unix 域套接字配置
+func (c *UnixSocketConfig) Validate() error {
+	if c.FileMode&^os.ModePerm != 0 {
+		return fmt.Errorf("server.unix_socket.file_mode: invalid permission %o", c.FileMode)
+	}
+	return nil
+}
+
+// removeStaleUnixSocket 删除上次异常退出遗留的套接字文件
+// 路径不是套接字文件，或仍有进程在该套接字上监听时返回错误
+func removeStaleUnixSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat unix socket %s: %w", path, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a unix socket", path)
+	}
+
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("unix socket %s is in use by another process", path)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale unix socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// listenUnix 创建 Unix 域套接字监听器：优先使用从父进程继承的监听器，否则清理遗留文件后新建
+// 套接字文件由 Engine.Stop 按配置删除，而不是在监听器关闭时删除，
+// 避免平滑重启时旧进程关闭监听器误删新进程仍在使用的套接字文件
+func listenUnix(name string, cfg *UnixSocketConfig) (net.Listener, bool, error) {
+	ln, err := takeInheritedListener(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if ln != nil {
+		if ul, ok := ln.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+		return ln, true, nil
+	}
+
+	if err := removeStaleUnixSocket(cfg.Path); err != nil {
+		return nil, false, err
+	}
+
+	ln, err = net.Listen("unix", cfg.Path)
+	if err != nil {
+		return nil, false, err
+	}
+	if ul, ok := ln.(*net.UnixListener); ok {
+		ul.SetUnlinkOnClose(false)
+	}
+
+	if err := os.Chmod(cfg.Path, cfg.FileMode); err != nil {
+		_ = ln.Close()
+		_ = os.Remove(cfg.Path)
+		return nil, false, fmt.Errorf("failed to chmod unix socket %s: %w", cfg.Path, err)
+	}
+	return ln, false, nil
+}
+
+// removeUnixSocket 删除套接字文件（已不存在时忽略）
+func removeUnixSocket(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
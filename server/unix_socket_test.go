@@ -0,0 +1,157 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// decodeUnixSocketConfig 通过 server 配置段解码 Unix 域套接字配置
+func decodeUnixSocketConfig(raw map[string]any) (*UnixSocketConfig, error) {
+	config := defaultServerConfig()
+	section := map[string]any{}
+	if raw != nil {
+		section["unix_socket"] = raw
+	}
+	if err := config.decode(section); err != nil {
+		return nil, err
+	}
+	return config.UnixSocket, nil
+}
+
+// TestDecodeUnixSocketConfig 测试 Unix 域套接字配置解析
+func TestDecodeUnixSocketConfig(t *testing.T) {
+	t.Run("未配置_使用默认值", func(t *testing.T) {
+		cfg, err := decodeUnixSocketConfig(nil)
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if cfg.Enabled() || cfg.FileMode != 0660 || !cfg.RemoveOnShutdown {
+			t.Errorf("默认配置不正确: %+v", cfg)
+		}
+	})
+
+	t.Run("完整配置_正确解析", func(t *testing.T) {
+		cfg, err := decodeUnixSocketConfig(map[string]any{
+			"path":               "/run/app/app.sock",
+			"file_mode":          "0600",
+			"remove_on_shutdown": false,
+		})
+		if err != nil {
+			t.Fatalf("未期望的错误: %v", err)
+		}
+		if !cfg.Enabled() || cfg.Path != "/run/app/app.sock" || cfg.FileMode != 0600 || cfg.RemoveOnShutdown {
+			t.Errorf("解析结果不正确: %+v", cfg)
+		}
+	})
+
+	t.Run("非法权限_返回错误", func(t *testing.T) {
+		for _, mode := range []any{"rw", "17777", true} {
+			if _, err := decodeUnixSocketConfig(map[string]any{"path": "a.sock", "file_mode": mode}); err == nil {
+				t.Errorf("期望 file_mode=%v 解析失败", mode)
+			}
+		}
+	})
+}
+
+// TestServerConfigUnixSocketAddress 测试启用 Unix 域套接字时的监听地址
+func TestServerConfigUnixSocketAddress(t *testing.T) {
+	config := defaultServerConfig()
+	if config.Network() != "tcp" {
+		t.Errorf("期望 Network = tcp, 实际 = %s", config.Network())
+	}
+
+	config.UnixSocket.Path = "/tmp/app.sock"
+	if config.Network() != "unix" || config.Address() != "/tmp/app.sock" {
+		t.Errorf("期望 unix /tmp/app.sock, 实际 %s %s", config.Network(), config.Address())
+	}
+}
+
+// TestListenUnix 测试 Unix 域套接字监听与遗留文件清理
+func TestListenUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("跳过 Windows 平台")
+	}
+
+	dir, err := os.MkdirTemp("", "litecore-sock")
+	if err != nil {
+		t.Fatalf("创建临时目录失败: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := &UnixSocketConfig{Path: filepath.Join(dir, "app.sock"), FileMode: 0600, RemoveOnShutdown: true}
+
+	t.Run("新建监听器_设置权限且关闭后保留文件", func(t *testing.T) {
+		ln, inherited, err := listenUnix(listenerHTTP, cfg)
+		if err != nil {
+			t.Fatalf("监听失败: %v", err)
+		}
+		if inherited {
+			t.Error("期望新建监听器")
+		}
+
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})}
+		go server.Serve(ln)
+
+		client := &http.Client{Transport: &http.Transport{
+			Dial: func(_, _ string) (net.Conn, error) { return net.Dial("unix", cfg.Path) },
+		}}
+		resp, err := client.Get("http://unix/")
+		if err != nil {
+			t.Fatalf("请求失败: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("期望状态码 204, 实际 %d", resp.StatusCode)
+		}
+
+		info, err := os.Stat(cfg.Path)
+		if err != nil {
+			t.Fatalf("获取套接字文件信息失败: %v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("期望权限 0600, 实际 %o", info.Mode().Perm())
+		}
+
+		t.Run("监听中_拒绝清理", func(t *testing.T) {
+			if err := removeStaleUnixSocket(cfg.Path); err == nil {
+				t.Error("期望套接字正在使用时返回错误")
+			}
+		})
+
+		_ = server.Close()
+		if _, err := os.Stat(cfg.Path); err != nil {
+			t.Errorf("关闭监听器后期望保留套接字文件: %v", err)
+		}
+	})
+
+	t.Run("遗留套接字_启动时清理", func(t *testing.T) {
+		ln, _, err := listenUnix(listenerHTTP, cfg)
+		if err != nil {
+			t.Fatalf("存在遗留套接字时监听失败: %v", err)
+		}
+		_ = ln.Close()
+
+		if err := removeUnixSocket(cfg.Path); err != nil {
+			t.Fatalf("删除套接字失败: %v", err)
+		}
+		if _, err := os.Stat(cfg.Path); !os.IsNotExist(err) {
+			t.Error("期望套接字文件已删除")
+		}
+	})
+
+	t.Run("普通文件_拒绝覆盖", func(t *testing.T) {
+		if err := os.WriteFile(cfg.Path, []byte("data"), 0600); err != nil {
+			t.Fatalf("写入文件失败: %v", err)
+		}
+		defer os.Remove(cfg.Path)
+
+		if _, _, err := listenUnix(listenerHTTP, cfg); err == nil {
+			t.Error("期望路径为普通文件时返回错误")
+		}
+	})
+}
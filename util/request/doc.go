@@ -20,7 +20,7 @@
 //	// 3. 在 Controller 中绑定和验证请求
 //	req, err := request.BindRequest[CreateUserRequest](ctx)
 //	if err != nil {
-//	    ctx.Error(apperrors.BadRequest("invalid request").Wrap(err))
+//	    ctx.Error(common.NewBadRequest("invalid request").Wrap(err))
 //	    return
 //	}
 //
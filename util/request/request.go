@@ -34,7 +34,7 @@ func GetDefaultValidator() ValidatorInterface {
 //
 //	req, err := request.BindRequest[dtos.ArticleCreateRequest](ctx)
 //	if err != nil {
-//	    ctx.Error(apperrors.BadRequest("invalid request").Wrap(err))
+//	    ctx.Error(common.NewBadRequest("invalid request").Wrap(err))
 //	    return
 //	}
 func BindRequest[T any](ctx *gin.Context) (*T, error) {
@@ -57,39 +57,52 @@ func (v *DefaultValidator) Validate(ctx *gin.Context, obj interface{}) error {
 // formatValidationError 格式化验证错误
 func (v *DefaultValidator) formatValidationError(err error) error {
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		var errMsgs []string
+		fields := make([]FieldError, 0, len(validationErrors))
+		errMsgs := make([]string, 0, len(validationErrors))
 		for _, e := range validationErrors {
 			field := e.Field()
 			tag := e.Tag()
 			param := e.Param()
 
+			var msg string
 			switch tag {
 			case "required":
-				errMsgs = append(errMsgs, field+" is required")
+				msg = field + " is required"
 			case "min":
-				errMsgs = append(errMsgs, field+" must be at least "+param+" characters")
+				msg = field + " must be at least " + param + " characters"
 			case "max":
-				errMsgs = append(errMsgs, field+" must be at most "+param+" characters")
+				msg = field + " must be at most " + param + " characters"
 			case "email":
-				errMsgs = append(errMsgs, field+" must be a valid email")
+				msg = field + " must be a valid email"
 			case "complexPassword":
-				errMsgs = append(errMsgs, field+
-					" must contain: at least 12 characters, uppercase, lowercase, number and special character")
+				msg = field + " must contain: at least 12 characters, uppercase, lowercase, number and special character"
 			default:
-				errMsgs = append(errMsgs, field+" validation failed on "+tag)
+				msg = field + " validation failed on " + tag
 			}
+			errMsgs = append(errMsgs, msg)
+			fields = append(fields, FieldError{Field: field, Tag: tag, Param: param, Message: msg})
 		}
 		return &ValidationError{
 			Message: strings.Join(errMsgs, "; "),
+			Fields:  fields,
 			Errors:  validationErrors,
 		}
 	}
 	return err
 }
 
+// FieldError 单个字段的验证错误
+type FieldError struct {
+	Field   string `json:"field"`           // 字段名（取 json 标签）
+	Tag     string `json:"tag"`             // 未通过的验证标签
+	Param   string `json:"param,omitempty"` // 验证标签参数
+	Message string `json:"message"`         // 错误描述
+}
+
 // ValidationError 验证错误
 type ValidationError struct {
 	Message string
+	Fields  []FieldError
 	Errors  validator.ValidationErrors
 }
 
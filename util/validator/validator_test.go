@@ -935,3 +935,27 @@ func TestRegisterValidationMultiple(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatValidationError_Fields 测试验证错误的字段明细
+func TestFormatValidationError_Fields(t *testing.T) {
+	v := NewDefaultValidator()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"","code":"1234"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	err := v.Validate(c, &struct {
+		Name string `json:"name" validate:"required"`
+		Code string `json:"code" validate:"max=3"`
+	}{})
+
+	var ve *ValidationError
+	if !assert.ErrorAs(t, err, &ve) {
+		return
+	}
+	assert.Equal(t, []FieldError{
+		{Field: "name", Tag: "required", Message: "name is required"},
+		{Field: "code", Tag: "max", Param: "3", Message: "code must be at most 3 characters"},
+	}, ve.Fields)
+}